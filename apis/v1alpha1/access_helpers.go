@@ -0,0 +1,120 @@
+package v1alpha1
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MinRetryMultiplier is the lower bound of the backoff policy multiplier.
+const MinRetryMultiplier = 1.0
+
+// IsAccessCredentialStale returns true if the credentials of the access ref are due for
+// rotation at now. Credentials without a rotation interval are never stale, while
+// credentials with an interval that were never rotated are stale.
+func IsAccessCredentialStale(ref AccessObjectRef, now time.Time) bool {
+	if ref.RotationIntervalDays <= 0 {
+		return false
+	}
+	if ref.LastRotatedAt == nil {
+		return true
+	}
+	interval := time.Duration(ref.RotationIntervalDays) * 24 * time.Hour
+	return !now.Before(ref.LastRotatedAt.Add(interval))
+}
+
+// ReferencedSecrets returns the keys of the secrets referenced by the KUBECONFIG access
+// refs of the clusters. Refs without a namespace are resolved to defaultNamespace. The
+// returned keys are interchangeable with the controller-runtime client.ObjectKey, so a
+// garbage collector can delete the access secrets missing from the set.
+func ReferencedSecrets(clusters []Cluster, defaultNamespace string) map[types.NamespacedName]struct{} {
+	secrets := map[types.NamespacedName]struct{}{}
+	for i := range clusters {
+		for _, ref := range clusters[i].Spec.AccessObjectRefs {
+			if ref.Type != AccessTypeKubeConfig || ref.ExternalSecretRef != nil || ref.Group != "" || ref.Resource != "secrets" {
+				continue
+			}
+			namespace := ref.Namespace
+			if namespace == "" {
+				namespace = defaultNamespace
+			}
+			secrets[types.NamespacedName{Namespace: namespace, Name: ref.Name}] = struct{}{}
+		}
+	}
+	return secrets
+}
+
+// GetAccessRefAnnotation returns the value of the annotation of the access ref with the
+// given key and whether it is present.
+func GetAccessRefAnnotation(ref AccessObjectRef, key string) (string, bool) {
+	value, ok := ref.Annotations[key]
+	return value, ok
+}
+
+// GetBackoffPolicy returns the backoff policy of the access ref with defaults applied to
+// unset fields. A nil policy returns the default policy.
+func (r AccessObjectRef) GetBackoffPolicy() BackoffPolicy {
+	var bp BackoffPolicy
+	if r.BackoffPolicy != nil {
+		bp = *r.BackoffPolicy
+	}
+	SetDefaults_BackoffPolicy(&bp)
+	return bp
+}
+
+// NextRetryInterval returns the interval to wait before retry number attempt, starting
+// at 0. The initial interval is multiplied by the multiplier for each attempt and
+// capped at the max interval. Unset fields of the policy take their default values.
+func NextRetryInterval(bp BackoffPolicy, attempt int) time.Duration {
+	SetDefaults_BackoffPolicy(&bp)
+	if attempt < 0 {
+		attempt = 0
+	}
+	multiplier, err := parseMultiplier(bp.Multiplier)
+	if err != nil {
+		multiplier, _ = parseMultiplier(DefaultRetryMultiplier)
+	}
+	if multiplier < MinRetryMultiplier {
+		multiplier = MinRetryMultiplier
+	}
+	max := time.Duration(bp.MaxIntervalSeconds) * time.Second
+	return exponentialBackoff(time.Duration(bp.InitialIntervalSeconds)*time.Second, max, multiplier, attempt)
+}
+
+// RetriesExhausted returns true if the policy allows no retry after attempt retries.
+func RetriesExhausted(bp BackoffPolicy, attempt int) bool {
+	return bp.MaxRetries > 0 && attempt >= int(bp.MaxRetries)
+}
+
+// NextAccessRef returns the index of the access ref to use after using the one at
+// currentIndex failed with lastError, following the failover strategy of the spec. A
+// negative currentIndex means no access ref was tried yet, and returns the first one.
+// A nil lastError keeps the current access ref. It returns false if there is no access
+// ref left to try.
+func NextAccessRef(spec ClusterSpec, currentIndex int, lastError error) (int, bool) {
+	n := len(spec.AccessObjectRefs)
+	if n == 0 {
+		return 0, false
+	}
+	if currentIndex < 0 || currentIndex >= n {
+		return 0, true
+	}
+	if lastError == nil {
+		return currentIndex, true
+	}
+
+	switch spec.FailoverStrategy {
+	case FailoverStrategyRoundRobin:
+		if n == 1 {
+			return currentIndex, false
+		}
+		return (currentIndex + 1) % n, true
+	case FailoverStrategyPriority:
+		if currentIndex+1 >= n {
+			return currentIndex, false
+		}
+		return currentIndex + 1, true
+	default:
+		return currentIndex, false
+	}
+}
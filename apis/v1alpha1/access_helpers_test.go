@@ -0,0 +1,286 @@
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestIsAccessCredentialStale(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	rotatedAgo := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(-d))
+		return &t
+	}
+
+	cases := []struct {
+		name     string
+		ref      AccessObjectRef
+		expected bool
+	}{
+		{
+			name: "no interval and never rotated",
+		},
+		{
+			name: "no interval",
+			ref:  AccessObjectRef{LastRotatedAt: rotatedAgo(1000 * 24 * time.Hour)},
+		},
+		{
+			name:     "interval set and never rotated",
+			ref:      AccessObjectRef{RotationIntervalDays: 30},
+			expected: true,
+		},
+		{
+			name: "just rotated",
+			ref:  AccessObjectRef{RotationIntervalDays: 30, LastRotatedAt: rotatedAgo(time.Hour)},
+		},
+		{
+			name:     "interval elapsed",
+			ref:      AccessObjectRef{RotationIntervalDays: 30, LastRotatedAt: rotatedAgo(31 * 24 * time.Hour)},
+			expected: true,
+		},
+		{
+			name:     "interval elapsed exactly",
+			ref:      AccessObjectRef{RotationIntervalDays: 30, LastRotatedAt: rotatedAgo(30 * 24 * time.Hour)},
+			expected: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := IsAccessCredentialStale(c.ref, now); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestReferencedSecrets(t *testing.T) {
+	cluster1 := newCluster("cluster1")
+	cluster1.Spec.AccessObjectRefs = []AccessObjectRef{
+		{Type: AccessTypeKubeConfig, Resource: "secrets", Name: "kubeconfig", Namespace: "fleet"},
+		{Type: AccessTypeKubeConfig, Resource: "secrets", Name: "default-kubeconfig"},
+		{Type: AccessTypeToken, Resource: "secrets", Name: "token", Namespace: "fleet"},
+		{Type: AccessTypeKubeConfig, Resource: "configmaps", Name: "not-a-secret", Namespace: "fleet"},
+		{Type: AccessTypeKubeConfig, Resource: "secrets", ExternalSecretRef: &ExternalSecretRef{Provider: ExternalSecretProviderVault, Path: "path"}},
+	}
+	cluster2 := newCluster("cluster2")
+	cluster2.Spec.AccessObjectRefs = []AccessObjectRef{
+		{Type: AccessTypeKubeConfig, Resource: "secrets", Name: "kubeconfig", Namespace: "fleet"},
+		{Type: AccessTypeKubeConfig, Resource: "secrets", Name: "kubeconfig", Namespace: "other"},
+	}
+
+	secrets := ReferencedSecrets([]Cluster{cluster1, cluster2}, "default-ns")
+	expected := []types.NamespacedName{
+		{Namespace: "fleet", Name: "kubeconfig"},
+		{Namespace: "default-ns", Name: "default-kubeconfig"},
+		{Namespace: "other", Name: "kubeconfig"},
+	}
+	if len(secrets) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, secrets)
+	}
+	for _, key := range expected {
+		if _, ok := secrets[key]; !ok {
+			t.Errorf("expected %v to be referenced, got %v", key, secrets)
+		}
+	}
+
+	if secrets := ReferencedSecrets(nil, "default-ns"); len(secrets) != 0 {
+		t.Errorf("expected no secrets, got %v", secrets)
+	}
+}
+
+func TestNextRetryInterval(t *testing.T) {
+	cases := []struct {
+		name     string
+		bp       BackoffPolicy
+		attempt  int
+		expected time.Duration
+	}{
+		{
+			name:     "defaults on first attempt",
+			expected: 5 * time.Second,
+		},
+		{
+			name:     "defaults double the interval",
+			attempt:  3,
+			expected: 40 * time.Second,
+		},
+		{
+			name:     "defaults are capped",
+			attempt:  10,
+			expected: 300 * time.Second,
+		},
+		{
+			name:     "negative attempt",
+			attempt:  -1,
+			expected: 5 * time.Second,
+		},
+		{
+			name:     "fractional multiplier",
+			bp:       BackoffPolicy{InitialIntervalSeconds: 4, Multiplier: "1.5"},
+			attempt:  2,
+			expected: 9 * time.Second,
+		},
+		{
+			name:     "multiplier below the minimum keeps the interval",
+			bp:       BackoffPolicy{InitialIntervalSeconds: 4, Multiplier: "0.5"},
+			attempt:  5,
+			expected: 4 * time.Second,
+		},
+		{
+			name:     "invalid multiplier uses the default",
+			bp:       BackoffPolicy{InitialIntervalSeconds: 4, Multiplier: "twice"},
+			attempt:  2,
+			expected: 16 * time.Second,
+		},
+		{
+			name:     "custom max",
+			bp:       BackoffPolicy{InitialIntervalSeconds: 10, MaxIntervalSeconds: 60, Multiplier: "3"},
+			attempt:  2,
+			expected: 60 * time.Second,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := NextRetryInterval(c.bp, c.attempt); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetBackoffPolicy(t *testing.T) {
+	defaults := BackoffPolicy{
+		InitialIntervalSeconds: DefaultRetryInitialIntervalSeconds,
+		MaxIntervalSeconds:     DefaultRetryMaxIntervalSeconds,
+		Multiplier:             DefaultRetryMultiplier,
+	}
+	if bp := (AccessObjectRef{}).GetBackoffPolicy(); bp != defaults {
+		t.Errorf("expected %v, got %v", defaults, bp)
+	}
+
+	ref := AccessObjectRef{BackoffPolicy: &BackoffPolicy{Multiplier: "1.5", MaxRetries: 3}}
+	expected := defaults
+	expected.Multiplier = "1.5"
+	expected.MaxRetries = 3
+	if bp := ref.GetBackoffPolicy(); bp != expected {
+		t.Errorf("expected %v, got %v", expected, bp)
+	}
+	if ref.BackoffPolicy.InitialIntervalSeconds != 0 {
+		t.Errorf("expected the policy of the access ref to be unchanged, got %v", ref.BackoffPolicy)
+	}
+}
+
+func TestRetriesExhausted(t *testing.T) {
+	cases := []struct {
+		name     string
+		bp       BackoffPolicy
+		attempt  int
+		expected bool
+	}{
+		{name: "unlimited", attempt: 100},
+		{name: "below max", bp: BackoffPolicy{MaxRetries: 3}, attempt: 2},
+		{name: "at max", bp: BackoffPolicy{MaxRetries: 3}, attempt: 3, expected: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := RetriesExhausted(c.bp, c.attempt); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNextAccessRef(t *testing.T) {
+	refs := []AccessObjectRef{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	failure := errors.New("connection refused")
+
+	cases := []struct {
+		name     string
+		strategy FailoverStrategy
+		refs     []AccessObjectRef
+		// attempts is the sequence of indexes returned by successive failures, starting
+		// from no access ref tried yet.
+		attempts []int
+		// exhausted is true if no access ref is left after the attempts.
+		exhausted bool
+	}{
+		{
+			name:     "round robin cycles through all refs",
+			strategy: FailoverStrategyRoundRobin,
+			refs:     refs,
+			attempts: []int{0, 1, 2, 0, 1, 2, 0},
+		},
+		{
+			name:      "round robin with a single ref",
+			strategy:  FailoverStrategyRoundRobin,
+			refs:      refs[:1],
+			attempts:  []int{0},
+			exhausted: true,
+		},
+		{
+			name:      "priority tries refs in order from the first",
+			strategy:  FailoverStrategyPriority,
+			refs:      refs,
+			attempts:  []int{0, 1, 2},
+			exhausted: true,
+		},
+		{
+			name:      "none keeps the first ref",
+			strategy:  FailoverStrategyNone,
+			refs:      refs,
+			attempts:  []int{0},
+			exhausted: true,
+		},
+		{
+			name:      "empty strategy is none",
+			refs:      refs,
+			attempts:  []int{0},
+			exhausted: true,
+		},
+		{
+			name:      "no refs",
+			strategy:  FailoverStrategyRoundRobin,
+			exhausted: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			spec := ClusterSpec{AccessObjectRefs: c.refs, FailoverStrategy: c.strategy}
+			index := -1
+			for i, expected := range c.attempts {
+				next, ok := NextAccessRef(spec, index, failure)
+				if !ok {
+					t.Fatalf("expected attempt %d to use ref %d, got none left", i, expected)
+				}
+				if next != expected {
+					t.Fatalf("expected attempt %d to use ref %d, got %d", i, expected, next)
+				}
+				index = next
+			}
+			if next, ok := NextAccessRef(spec, index, failure); ok == c.exhausted {
+				t.Errorf("expected exhausted %v after %d attempts, got ref %d", c.exhausted, len(c.attempts), next)
+			}
+		})
+	}
+}
+
+func TestNextAccessRefWithoutError(t *testing.T) {
+	spec := ClusterSpec{
+		AccessObjectRefs: []AccessObjectRef{{Name: "a"}, {Name: "b"}},
+		FailoverStrategy: FailoverStrategyRoundRobin,
+	}
+	if next, ok := NextAccessRef(spec, 1, nil); !ok || next != 1 {
+		t.Errorf("expected the current ref to be kept, got %d and %v", next, ok)
+	}
+	if next, ok := NextAccessRef(spec, 5, errors.New("failed")); !ok || next != 0 {
+		t.Errorf("expected an out of range index to restart from the first ref, got %d and %v", next, ok)
+	}
+}
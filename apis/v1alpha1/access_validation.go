@@ -0,0 +1,195 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	minTokenExpirationSeconds = 600
+	maxRotationIntervalDays   = 365
+
+	maxAccessRefAnnotations           = 16
+	maxAccessRefAnnotationValueLength = 512
+)
+
+// ValidateAccessObjectRefs validates the access refs of a cluster.
+func ValidateAccessObjectRefs(refs []AccessObjectRef, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, ref := range refs {
+		refPath := fldPath.Index(i)
+		allErrs = append(allErrs, ValidateServiceAccountAccessConfig(ref.ServiceAccountRef, refPath.Child("serviceAccountRef"))...)
+		allErrs = append(allErrs, ValidateExternalSecretRef(ref.ExternalSecretRef, refPath.Child("externalSecretRef"))...)
+		allErrs = append(allErrs, ValidateBackoffPolicy(ref.BackoffPolicy, refPath.Child("backoffPolicy"))...)
+		allErrs = append(allErrs, validateAccessRefAnnotations(ref.Annotations, refPath.Child("annotations"))...)
+		allErrs = append(allErrs, validateAccessRefCredentials(ref, refPath)...)
+		if ref.RotationIntervalDays != 0 && (ref.RotationIntervalDays < 1 || ref.RotationIntervalDays > maxRotationIntervalDays) {
+			allErrs = append(allErrs, field.Invalid(refPath.Child("rotationIntervalDays"), ref.RotationIntervalDays,
+				fmt.Sprintf("must be between 1 and %d", maxRotationIntervalDays)))
+		}
+	}
+	return allErrs
+}
+
+// validateAccessRefCredentials checks that the access ref sets exactly the credential
+// fields of its type, mirroring the CEL rules of AccessObjectRef. A SERVICEACCOUNT ref
+// sets only the service account ref, while other refs set either a name and namespace
+// or an external secret ref.
+func validateAccessRefCredentials(ref AccessObjectRef, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ref.Type == AccessTypeServiceAccount {
+		if ref.ServiceAccountRef == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("serviceAccountRef"), "serviceAccountRef is required for type SERVICEACCOUNT"))
+		}
+		if ref.Name != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "may not be set for type SERVICEACCOUNT"))
+		}
+		if ref.ExternalSecretRef != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("externalSecretRef"), "may not be set for type SERVICEACCOUNT"))
+		}
+		return allErrs
+	}
+
+	if ref.ServiceAccountRef != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("serviceAccountRef"), "may only be set for type SERVICEACCOUNT"))
+	}
+	switch {
+	case ref.ExternalSecretRef != nil && (ref.Name != "" || ref.Namespace != ""):
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("externalSecretRef"), "may not be set together with name or namespace"))
+	case ref.ExternalSecretRef == nil && ref.Name == "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "name is required unless externalSecretRef is set"))
+	}
+	return allErrs
+}
+
+// ValidateServiceAccountAccessConfig validates the service account access config of an
+// access ref. A nil config is valid.
+func ValidateServiceAccountAccessConfig(config *ServiceAccountAccessConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if config == nil {
+		return allErrs
+	}
+
+	for _, msg := range validation.IsDNS1123Subdomain(config.ServiceAccountName) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceAccountName"), config.ServiceAccountName, msg))
+	}
+	if config.TokenExpirationSeconds != nil && *config.TokenExpirationSeconds < minTokenExpirationSeconds {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("tokenExpirationSeconds"), *config.TokenExpirationSeconds,
+			fmt.Sprintf("must be at least %d", minTokenExpirationSeconds)))
+	}
+	for i, audience := range config.Audiences {
+		if len(audience) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("audiences").Index(i), "audience must not be empty"))
+		}
+	}
+	return allErrs
+}
+
+// ValidateExternalSecretRef validates the external secret ref of an access ref. A nil
+// ref is valid.
+func ValidateExternalSecretRef(ref *ExternalSecretRef, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ref == nil {
+		return allErrs
+	}
+
+	switch ref.Provider {
+	case ExternalSecretProviderVault, ExternalSecretProviderAWS, ExternalSecretProviderGCP:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("provider"), ref.Provider,
+			[]string{ExternalSecretProviderVault, ExternalSecretProviderAWS, ExternalSecretProviderGCP}))
+	}
+	if ref.Path == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("path"), ""))
+	}
+	return allErrs
+}
+
+// ValidateBackoffPolicy validates the backoff policy of an access ref. A nil policy is
+// valid.
+func ValidateBackoffPolicy(bp *BackoffPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if bp == nil {
+		return allErrs
+	}
+
+	if bp.InitialIntervalSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("initialIntervalSeconds"), bp.InitialIntervalSeconds, "must not be negative"))
+	}
+	if bp.MaxIntervalSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxIntervalSeconds"), bp.MaxIntervalSeconds, "must not be negative"))
+	}
+	if bp.InitialIntervalSeconds > 0 && bp.MaxIntervalSeconds > 0 && bp.InitialIntervalSeconds > bp.MaxIntervalSeconds {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("initialIntervalSeconds"), bp.InitialIntervalSeconds,
+			"must not be greater than maxIntervalSeconds"))
+	}
+	if bp.Multiplier != "" {
+		if multiplier, err := parseMultiplier(bp.Multiplier); err != nil || multiplier < MinRetryMultiplier {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("multiplier"), bp.Multiplier,
+				fmt.Sprintf("must be a decimal number of at least %g", MinRetryMultiplier)))
+		}
+	}
+	if bp.MaxRetries < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxRetries"), bp.MaxRetries, "must not be negative"))
+	}
+	return allErrs
+}
+
+func validateAccessRefAnnotations(annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(annotations) > maxAccessRefAnnotations {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(annotations), maxAccessRefAnnotations))
+	}
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if len(annotations[key]) > maxAccessRefAnnotationValueLength {
+			allErrs = append(allErrs, field.TooLong(fldPath.Key(key), annotations[key], maxAccessRefAnnotationValueLength))
+		}
+	}
+	return allErrs
+}
+
+// ValidateAccessSecret validates the secret referenced by an access ref. The secret
+// type and the keys required by the access type are checked, and errors point at the
+// data keys of the secret.
+func ValidateAccessSecret(ref AccessObjectRef, secret *corev1.Secret) field.ErrorList {
+	allErrs := field.ErrorList{}
+	typePath := field.NewPath("type")
+	dataPath := field.NewPath("data")
+
+	switch ref.Type {
+	case AccessTypeKubeConfig:
+		if secret.Type != corev1.SecretTypeOpaque {
+			allErrs = append(allErrs, field.NotSupported(typePath, secret.Type, []string{string(corev1.SecretTypeOpaque)}))
+		}
+		allErrs = append(allErrs, validateSecretKeys(secret, dataPath, KubeConfigSecretKey)...)
+	case AccessTypeToken:
+		if secret.Type != corev1.SecretTypeOpaque && secret.Type != corev1.SecretTypeServiceAccountToken {
+			allErrs = append(allErrs, field.NotSupported(typePath, secret.Type,
+				[]string{string(corev1.SecretTypeOpaque), string(corev1.SecretTypeServiceAccountToken)}))
+		}
+		allErrs = append(allErrs, validateSecretKeys(secret, dataPath, TokenSecretKey, CASecretKey)...)
+	default:
+		allErrs = append(allErrs, field.NotSupported(typePath, ref.Type,
+			[]string{string(AccessTypeKubeConfig), string(AccessTypeToken)}))
+	}
+	return allErrs
+}
+
+func validateSecretKeys(secret *corev1.Secret, fldPath *field.Path, keys ...string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, key := range keys {
+		if len(secret.Data[key]) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Key(key), ""))
+		}
+	}
+	return allErrs
+}
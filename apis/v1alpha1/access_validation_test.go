@@ -0,0 +1,494 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateAccessSecret(t *testing.T) {
+	kubeconfig := AccessObjectRef{Type: AccessTypeKubeConfig}
+	token := AccessObjectRef{Type: AccessTypeToken}
+
+	cases := []struct {
+		name           string
+		ref            AccessObjectRef
+		secretType     corev1.SecretType
+		data           map[string][]byte
+		expectedFields []string
+	}{
+		{
+			name:       "valid kubeconfig secret",
+			ref:        kubeconfig,
+			secretType: corev1.SecretTypeOpaque,
+			data:       map[string][]byte{KubeConfigSecretKey: []byte("kubeconfig")},
+		},
+		{
+			name:           "kubeconfig secret without kubeconfig",
+			ref:            kubeconfig,
+			secretType:     corev1.SecretTypeOpaque,
+			data:           map[string][]byte{KubeConfigSecretKey: {}},
+			expectedFields: []string{"data[" + KubeConfigSecretKey + "]"},
+		},
+		{
+			name:           "kubeconfig secret of the wrong type",
+			ref:            kubeconfig,
+			secretType:     corev1.SecretTypeServiceAccountToken,
+			data:           map[string][]byte{KubeConfigSecretKey: []byte("kubeconfig")},
+			expectedFields: []string{"type"},
+		},
+		{
+			name:       "valid opaque token secret",
+			ref:        token,
+			secretType: corev1.SecretTypeOpaque,
+			data:       map[string][]byte{TokenSecretKey: []byte("token"), CASecretKey: []byte("ca")},
+		},
+		{
+			name:       "valid service account token secret",
+			ref:        token,
+			secretType: corev1.SecretTypeServiceAccountToken,
+			data:       map[string][]byte{TokenSecretKey: []byte("token"), CASecretKey: []byte("ca")},
+		},
+		{
+			name:           "token secret without keys",
+			ref:            token,
+			secretType:     corev1.SecretTypeDockerConfigJson,
+			expectedFields: []string{"type", "data[" + TokenSecretKey + "]", "data[" + CASecretKey + "]"},
+		},
+		{
+			name:           "unsupported access type",
+			ref:            AccessObjectRef{Type: "UNKNOWN"},
+			secretType:     corev1.SecretTypeOpaque,
+			expectedFields: []string{"type"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := ValidateAccessSecret(c.ref, &corev1.Secret{Type: c.secretType, Data: c.data})
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateServiceAccountAccessConfig(t *testing.T) {
+	expiration := int64(3600)
+	tooShort := int64(minTokenExpirationSeconds - 1)
+
+	cases := []struct {
+		name           string
+		config         *ServiceAccountAccessConfig
+		expectedFields []string
+	}{
+		{
+			name: "nil config",
+		},
+		{
+			name:   "minimal config",
+			config: &ServiceAccountAccessConfig{ServiceAccountName: "cluster-reader"},
+		},
+		{
+			name: "full config",
+			config: &ServiceAccountAccessConfig{
+				ServiceAccountName:     "cluster-reader",
+				TokenExpirationSeconds: &expiration,
+				Audiences:              []string{"https://kubernetes.default.svc"},
+			},
+		},
+		{
+			name:           "invalid name",
+			config:         &ServiceAccountAccessConfig{ServiceAccountName: "Cluster_Reader"},
+			expectedFields: []string{"serviceAccountRef.serviceAccountName"},
+		},
+		{
+			name:           "missing name",
+			config:         &ServiceAccountAccessConfig{},
+			expectedFields: []string{"serviceAccountRef.serviceAccountName"},
+		},
+		{
+			name: "short expiry and empty audience",
+			config: &ServiceAccountAccessConfig{
+				ServiceAccountName:     "cluster-reader",
+				TokenExpirationSeconds: &tooShort,
+				Audiences:              []string{"api", ""},
+			},
+			expectedFields: []string{"serviceAccountRef.tokenExpirationSeconds", "serviceAccountRef.audiences[1]"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := ValidateServiceAccountAccessConfig(c.config, field.NewPath("serviceAccountRef"))
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateAccessObjectRefs(t *testing.T) {
+	newRef := func(mutate func(ref *AccessObjectRef)) AccessObjectRef {
+		ref := AccessObjectRef{Type: AccessTypeKubeConfig, Resource: "secrets", Name: "kubeconfig", Namespace: "fleet"}
+		mutate(&ref)
+		return ref
+	}
+
+	cases := []struct {
+		name           string
+		ref            AccessObjectRef
+		expectedFields []string
+	}{
+		{
+			name: "valid",
+			ref:  newRef(func(ref *AccessObjectRef) {}),
+		},
+		{
+			name: "no rotation interval",
+			ref:  newRef(func(ref *AccessObjectRef) { ref.RotationIntervalDays = 0 }),
+		},
+		{
+			name: "min rotation interval",
+			ref:  newRef(func(ref *AccessObjectRef) { ref.RotationIntervalDays = 1 }),
+		},
+		{
+			name: "max rotation interval",
+			ref:  newRef(func(ref *AccessObjectRef) { ref.RotationIntervalDays = maxRotationIntervalDays }),
+		},
+		{
+			name:           "negative rotation interval",
+			ref:            newRef(func(ref *AccessObjectRef) { ref.RotationIntervalDays = -1 }),
+			expectedFields: []string{"refs[0].rotationIntervalDays"},
+		},
+		{
+			name:           "rotation interval too long",
+			ref:            newRef(func(ref *AccessObjectRef) { ref.RotationIntervalDays = maxRotationIntervalDays + 1 }),
+			expectedFields: []string{"refs[0].rotationIntervalDays"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := ValidateAccessObjectRefs([]AccessObjectRef{c.ref}, field.NewPath("refs"))
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateAccessRefCredentials(t *testing.T) {
+	serviceAccount := &ServiceAccountAccessConfig{ServiceAccountName: "cluster-reader"}
+	vault := &ExternalSecretRef{Provider: ExternalSecretProviderVault, Path: "cluster1"}
+
+	cases := []struct {
+		name              string
+		accessType        AccessType
+		refName           string
+		namespace         string
+		serviceAccountRef *ServiceAccountAccessConfig
+		externalSecretRef *ExternalSecretRef
+		expectedFields    []string
+	}{
+		{
+			name:       "kubeconfig with name and namespace",
+			accessType: AccessTypeKubeConfig,
+			refName:    "kubeconfig",
+			namespace:  "fleet",
+		},
+		{
+			name:       "kubeconfig with name only",
+			accessType: AccessTypeKubeConfig,
+			refName:    "kubeconfig",
+		},
+		{
+			name:              "kubeconfig with external secret ref",
+			accessType:        AccessTypeKubeConfig,
+			externalSecretRef: vault,
+		},
+		{
+			name:       "token with name",
+			accessType: AccessTypeToken,
+			refName:    "token",
+			namespace:  "fleet",
+		},
+		{
+			name:              "service account with service account ref",
+			accessType:        AccessTypeServiceAccount,
+			serviceAccountRef: serviceAccount,
+		},
+		{
+			name:              "service account with service account ref and namespace",
+			accessType:        AccessTypeServiceAccount,
+			namespace:         "fleet",
+			serviceAccountRef: serviceAccount,
+		},
+		{
+			name:           "kubeconfig without name",
+			accessType:     AccessTypeKubeConfig,
+			namespace:      "fleet",
+			expectedFields: []string{"ref.name"},
+		},
+		{
+			name:              "kubeconfig with name and external secret ref",
+			accessType:        AccessTypeKubeConfig,
+			refName:           "kubeconfig",
+			externalSecretRef: vault,
+			expectedFields:    []string{"ref.externalSecretRef"},
+		},
+		{
+			name:              "kubeconfig with namespace and external secret ref",
+			accessType:        AccessTypeKubeConfig,
+			namespace:         "fleet",
+			externalSecretRef: vault,
+			expectedFields:    []string{"ref.externalSecretRef"},
+		},
+		{
+			name:              "kubeconfig with service account ref",
+			accessType:        AccessTypeKubeConfig,
+			refName:           "kubeconfig",
+			serviceAccountRef: serviceAccount,
+			expectedFields:    []string{"ref.serviceAccountRef"},
+		},
+		{
+			name:              "token with service account ref only",
+			accessType:        AccessTypeToken,
+			serviceAccountRef: serviceAccount,
+			expectedFields:    []string{"ref.serviceAccountRef", "ref.name"},
+		},
+		{
+			name:           "service account without service account ref",
+			accessType:     AccessTypeServiceAccount,
+			expectedFields: []string{"ref.serviceAccountRef"},
+		},
+		{
+			name:              "service account with name",
+			accessType:        AccessTypeServiceAccount,
+			refName:           "kubeconfig",
+			serviceAccountRef: serviceAccount,
+			expectedFields:    []string{"ref.name"},
+		},
+		{
+			name:              "service account with external secret ref",
+			accessType:        AccessTypeServiceAccount,
+			serviceAccountRef: serviceAccount,
+			externalSecretRef: vault,
+			expectedFields:    []string{"ref.externalSecretRef"},
+		},
+		{
+			name:              "service account with only name and external secret ref",
+			accessType:        AccessTypeServiceAccount,
+			refName:           "kubeconfig",
+			externalSecretRef: vault,
+			expectedFields:    []string{"ref.serviceAccountRef", "ref.name", "ref.externalSecretRef"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ref := AccessObjectRef{
+				Type:              c.accessType,
+				Resource:          "secrets",
+				Name:              c.refName,
+				Namespace:         c.namespace,
+				ServiceAccountRef: c.serviceAccountRef,
+				ExternalSecretRef: c.externalSecretRef,
+			}
+			errs := validateAccessRefCredentials(ref, field.NewPath("ref"))
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateExternalSecretRef(t *testing.T) {
+	cases := []struct {
+		name           string
+		ref            *ExternalSecretRef
+		expectedFields []string
+	}{
+		{
+			name: "nil ref",
+		},
+		{
+			name: "vault",
+			ref:  &ExternalSecretRef{Provider: ExternalSecretProviderVault, Path: "secret/data/cluster1"},
+		},
+		{
+			name: "aws",
+			ref:  &ExternalSecretRef{Provider: ExternalSecretProviderAWS, Path: "cluster1"},
+		},
+		{
+			name: "gcp",
+			ref:  &ExternalSecretRef{Provider: ExternalSecretProviderGCP, Path: "projects/p/secrets/cluster1"},
+		},
+		{
+			name:           "unsupported provider",
+			ref:            &ExternalSecretRef{Provider: "Azure", Path: "cluster1"},
+			expectedFields: []string{"externalSecretRef.provider"},
+		},
+		{
+			name:           "missing provider and path",
+			ref:            &ExternalSecretRef{},
+			expectedFields: []string{"externalSecretRef.provider", "externalSecretRef.path"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := ValidateExternalSecretRef(c.ref, field.NewPath("externalSecretRef"))
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateAccessObjectRefsExternalSecret(t *testing.T) {
+	external := &ExternalSecretRef{Provider: ExternalSecretProviderVault, Path: "secret/data/cluster1"}
+
+	cases := []struct {
+		name           string
+		ref            AccessObjectRef
+		expectedFields []string
+	}{
+		{
+			name: "external secret ref only",
+			ref:  AccessObjectRef{Type: AccessTypeKubeConfig, ExternalSecretRef: external},
+		},
+		{
+			name:           "external secret ref with name",
+			ref:            AccessObjectRef{Type: AccessTypeKubeConfig, Name: "kubeconfig", ExternalSecretRef: external},
+			expectedFields: []string{"refs[0].externalSecretRef"},
+		},
+		{
+			name:           "external secret ref with namespace",
+			ref:            AccessObjectRef{Type: AccessTypeKubeConfig, Namespace: "fleet", ExternalSecretRef: external},
+			expectedFields: []string{"refs[0].externalSecretRef"},
+		},
+		{
+			name:           "invalid external secret ref",
+			ref:            AccessObjectRef{Type: AccessTypeKubeConfig, ExternalSecretRef: &ExternalSecretRef{Provider: ExternalSecretProviderAWS}},
+			expectedFields: []string{"refs[0].externalSecretRef.path"},
+		},
+		{
+			name:           "neither name nor external secret ref",
+			ref:            AccessObjectRef{Type: AccessTypeKubeConfig, Namespace: "fleet"},
+			expectedFields: []string{"refs[0].name"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := ValidateAccessObjectRefs([]AccessObjectRef{c.ref}, field.NewPath("refs"))
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateBackoffPolicy(t *testing.T) {
+	cases := []struct {
+		name           string
+		bp             *BackoffPolicy
+		expectedFields []string
+	}{
+		{
+			name: "nil policy",
+		},
+		{
+			name: "empty policy",
+			bp:   &BackoffPolicy{},
+		},
+		{
+			name: "valid policy",
+			bp:   &BackoffPolicy{InitialIntervalSeconds: 5, MaxIntervalSeconds: 300, Multiplier: "1", MaxRetries: 10},
+		},
+		{
+			name:           "multiplier below the minimum",
+			bp:             &BackoffPolicy{Multiplier: "0.9"},
+			expectedFields: []string{"backoffPolicy.multiplier"},
+		},
+		{
+			name:           "multiplier not a number",
+			bp:             &BackoffPolicy{Multiplier: "Inf"},
+			expectedFields: []string{"backoffPolicy.multiplier"},
+		},
+		{
+			name:           "negative values",
+			bp:             &BackoffPolicy{InitialIntervalSeconds: -1, MaxIntervalSeconds: -1, MaxRetries: -1},
+			expectedFields: []string{"backoffPolicy.initialIntervalSeconds", "backoffPolicy.maxIntervalSeconds", "backoffPolicy.maxRetries"},
+		},
+		{
+			name:           "initial interval above max",
+			bp:             &BackoffPolicy{InitialIntervalSeconds: 60, MaxIntervalSeconds: 30},
+			expectedFields: []string{"backoffPolicy.initialIntervalSeconds"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := ValidateBackoffPolicy(c.bp, field.NewPath("backoffPolicy"))
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateAccessRefAnnotations(t *testing.T) {
+	tooMany := map[string]string{}
+	for i := 0; i <= maxAccessRefAnnotations; i++ {
+		tooMany[fmt.Sprintf("key%d", i)] = "value"
+	}
+
+	cases := []struct {
+		name           string
+		annotations    map[string]string
+		expectedFields []string
+	}{
+		{
+			name: "no annotations",
+		},
+		{
+			name:        "valid annotations",
+			annotations: map[string]string{"example.com/owner": "team-a", "example.com/empty": ""},
+		},
+		{
+			name:           "too many annotations",
+			annotations:    tooMany,
+			expectedFields: []string{"annotations"},
+		},
+		{
+			name: "values too long",
+			annotations: map[string]string{
+				"b":   strings.Repeat("x", maxAccessRefAnnotationValueLength+1),
+				"a":   strings.Repeat("x", maxAccessRefAnnotationValueLength+1),
+				"max": strings.Repeat("x", maxAccessRefAnnotationValueLength),
+			},
+			expectedFields: []string{"annotations[a]", "annotations[b]"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := validateAccessRefAnnotations(c.annotations, field.NewPath("annotations"))
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+func TestGetAccessRefAnnotation(t *testing.T) {
+	ref := AccessObjectRef{Annotations: map[string]string{"example.com/owner": "team-a", "example.com/empty": ""}}
+
+	cases := []struct {
+		key           string
+		expected      string
+		expectedFound bool
+	}{
+		{key: "example.com/owner", expected: "team-a", expectedFound: true},
+		{key: "example.com/empty", expectedFound: true},
+		{key: "example.com/missing"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.key, func(t *testing.T) {
+			value, found := GetAccessRefAnnotation(ref, c.key)
+			if value != c.expected || found != c.expectedFound {
+				t.Errorf("expected (%q, %v), got (%q, %v)", c.expected, c.expectedFound, value, found)
+			}
+		})
+	}
+}
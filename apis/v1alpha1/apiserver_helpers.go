@@ -0,0 +1,74 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// APIServerURLMatchesCertSANs returns true if the hostname of the API server URL of the
+// cluster is covered by one of its API server certificate SANs. IP addresses must
+// equal an IP SAN, while hostnames match DNS SANs case-insensitively, with a wildcard
+// SAN such as *.example.com matching exactly one leftmost label. An error is returned
+// if the URL is empty or has no hostname.
+func APIServerURLMatchesCertSANs(cluster Cluster) (bool, error) {
+	if cluster.Status.APIServerURL == "" {
+		return false, fmt.Errorf("cluster %q has no API server URL", cluster.Name)
+	}
+	u, err := url.Parse(cluster.Status.APIServerURL)
+	if err != nil {
+		return false, fmt.Errorf("invalid API server URL %q: %w", cluster.Status.APIServerURL, err)
+	}
+	host := u.Hostname()
+	if host == "" {
+		return false, fmt.Errorf("API server URL %q has no hostname", cluster.Status.APIServerURL)
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		for _, san := range cluster.Status.APIServerCertSANs {
+			if sanIP := net.ParseIP(san); sanIP != nil && sanIP.Equal(ip) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, san := range cluster.Status.APIServerCertSANs {
+		if matchesDNSSAN(host, strings.ToLower(strings.TrimSuffix(san, "."))) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func matchesDNSSAN(host, san string) bool {
+	if san == host {
+		return true
+	}
+	if !strings.HasPrefix(san, "*.") {
+		return false
+	}
+	suffix := strings.TrimPrefix(san, "*.")
+	label, rest, found := strings.Cut(host, ".")
+	return found && label != "" && rest == suffix
+}
+
+// Endpoint returns the control plane endpoint of the cluster. It prefers the
+// ControlPlaneEndpoint of the status and falls back to the APIServerURL, then to the
+// last known API server URL of a disconnected cluster. Reading the endpoint from the
+// kubeconfig of an access ref requires access to its secret, see the accessutil
+// package. It returns an empty string if none is known.
+func (c *Cluster) Endpoint() string {
+	switch {
+	case c.Status.ControlPlaneEndpoint != "":
+		return c.Status.ControlPlaneEndpoint
+	case c.Status.APIServerURL != "":
+		return c.Status.APIServerURL
+	case c.Status.Disconnected != nil:
+		return c.Status.Disconnected.LastKnownAPIServerURL
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,138 @@
+package v1alpha1
+
+import "testing"
+
+func TestAPIServerURLMatchesCertSANs(t *testing.T) {
+	cases := []struct {
+		name        string
+		url         string
+		sans        []string
+		expected    bool
+		expectError bool
+	}{
+		{
+			name:     "exact hostname",
+			url:      "https://api.example.com:6443",
+			sans:     []string{"kubernetes", "api.example.com"},
+			expected: true,
+		},
+		{
+			name:     "hostname case and trailing dot",
+			url:      "https://API.Example.com.:6443",
+			sans:     []string{"api.example.com"},
+			expected: true,
+		},
+		{
+			name:     "wildcard",
+			url:      "https://api.example.com",
+			sans:     []string{"*.example.com"},
+			expected: true,
+		},
+		{
+			name: "wildcard matches only one label",
+			url:  "https://api.eu.example.com",
+			sans: []string{"*.example.com"},
+		},
+		{
+			name: "wildcard does not match the bare domain",
+			url:  "https://example.com",
+			sans: []string{"*.example.com"},
+		},
+		{
+			name:     "ip address",
+			url:      "https://10.0.0.1:6443",
+			sans:     []string{"api.example.com", "10.0.0.1"},
+			expected: true,
+		},
+		{
+			name:     "ipv6 address",
+			url:      "https://[fd00::1]:6443",
+			sans:     []string{"fd00:0:0:0:0:0:0:1"},
+			expected: true,
+		},
+		{
+			name: "ip address does not match dns sans",
+			url:  "https://10.0.0.1",
+			sans: []string{"*.0.0.1"},
+		},
+		{
+			name: "no sans",
+			url:  "https://api.example.com",
+		},
+		{
+			name:        "no url",
+			sans:        []string{"api.example.com"},
+			expectError: true,
+		},
+		{
+			name:        "url without hostname",
+			url:         "/api",
+			expectError: true,
+		},
+		{
+			name:        "invalid url",
+			url:         "https://api example.com",
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.Status.APIServerURL = c.url
+			cluster.Status.APIServerCertSANs = c.sans
+
+			matches, err := APIServerURLMatchesCertSANs(cluster)
+			if c.expectError != (err != nil) {
+				t.Fatalf("expected error %v, got %v", c.expectError, err)
+			}
+			if matches != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, matches)
+			}
+		})
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   ClusterStatus
+		expected string
+	}{
+		{
+			name: "no endpoint",
+		},
+		{
+			name: "control plane endpoint preferred",
+			status: ClusterStatus{
+				ControlPlaneEndpoint: "https://cp.example.com:6443",
+				APIServerURL:         "https://api.example.com:6443",
+				Disconnected:         &DisconnectedState{LastKnownAPIServerURL: "https://old.example.com:6443"},
+			},
+			expected: "https://cp.example.com:6443",
+		},
+		{
+			name: "api server url",
+			status: ClusterStatus{
+				APIServerURL: "https://api.example.com:6443",
+				Disconnected: &DisconnectedState{LastKnownAPIServerURL: "https://old.example.com:6443"},
+			},
+			expected: "https://api.example.com:6443",
+		},
+		{
+			name:     "last known api server url",
+			status:   ClusterStatus{Disconnected: &DisconnectedState{LastKnownAPIServerURL: "https://old.example.com:6443"}},
+			expected: "https://old.example.com:6443",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.Status = c.status
+			if actual := cluster.Endpoint(); actual != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// StatusApplyConfiguration returns a server-side apply configuration of the cluster
+// containing only its type, name, namespace and status, suitable for
+// client.Status().Patch(ctx, obj, client.Apply, client.FieldOwner(...)). Null values,
+// empty objects and empty lists are pruned from the status so that the field manager
+// only claims ownership of the fields that are actually set.
+func (c *Cluster) StatusApplyConfiguration() (*unstructured.Unstructured, error) {
+	status, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&c.Status)
+	if err != nil {
+		return nil, err
+	}
+	pruneEmptyFields(status)
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": status,
+	}}
+	obj.SetGroupVersionKind(GroupVersion.WithKind("Cluster"))
+	obj.SetName(c.Name)
+	obj.SetNamespace(c.Namespace)
+	return obj, nil
+}
+
+// pruneEmptyFields recursively removes the fields of obj whose value is null, an empty
+// object or an empty list once pruned. List items are pruned but never removed, as
+// that would change the meaning of the list.
+func pruneEmptyFields(obj map[string]interface{}) {
+	for key, value := range obj {
+		if isEmptyValue(pruneValue(value)) {
+			delete(obj, key)
+		}
+	}
+}
+
+func pruneValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		pruneEmptyFields(v)
+	case []interface{}:
+		for _, item := range v {
+			pruneValue(item)
+		}
+	}
+	return value
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStatusApplyConfiguration(t *testing.T) {
+	cases := []struct {
+		name           string
+		status         ClusterStatus
+		expectedFields []string
+	}{
+		{
+			name: "empty status",
+		},
+		{
+			name: "conditions and version",
+			status: ClusterStatus{
+				Conditions: []metav1.Condition{{
+					Type:               ClusterConditionJoined,
+					Status:             metav1.ConditionTrue,
+					Reason:             "Joined",
+					LastTransitionTime: metav1.Now(),
+				}},
+				Version: ClusterVersion{Kubernetes: "v1.28.0"},
+			},
+			expectedFields: []string{"conditions", "version"},
+		},
+		{
+			name: "empty lists and nested objects are pruned",
+			status: ClusterStatus{
+				Conditions: []metav1.Condition{},
+				Resources: Resources{
+					Capacity:    ResourceList{ResourceCPU: resource.MustParse("4")},
+					Allocatable: ResourceList{},
+				},
+				Namespaces: []string{},
+			},
+			expectedFields: []string{"resources"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.Namespace = "fleet"
+			cluster.Labels = map[string]string{"env": "prod"}
+			cluster.Spec.Taints = []Taint{{Key: "example.com/maintenance", Effect: TaintEffectNoSelect}}
+			cluster.Status = c.status
+
+			obj, err := cluster.StatusApplyConfiguration()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gvk := obj.GroupVersionKind(); gvk != GroupVersion.WithKind("Cluster") {
+				t.Errorf("expected kind Cluster, got %v", gvk)
+			}
+			if obj.GetName() != "cluster1" || obj.GetNamespace() != "fleet" {
+				t.Errorf("unexpected cluster %s/%s", obj.GetNamespace(), obj.GetName())
+			}
+			if len(obj.GetLabels()) > 0 {
+				t.Errorf("expected no labels, got %v", obj.GetLabels())
+			}
+
+			var topLevelFields []string
+			for field := range obj.Object {
+				topLevelFields = append(topLevelFields, field)
+			}
+			sort.Strings(topLevelFields)
+			assertStrings(t, topLevelFields, []string{"apiVersion", "kind", "metadata", "status"})
+
+			status, ok := obj.Object["status"].(map[string]interface{})
+			if !ok {
+				t.Fatalf("expected a status object, got %#v", obj.Object["status"])
+			}
+			var statusFields []string
+			for field := range status {
+				statusFields = append(statusFields, field)
+			}
+			sort.Strings(statusFields)
+			assertStrings(t, statusFields, c.expectedFields)
+		})
+	}
+}
+
+func TestPruneEmptyFields(t *testing.T) {
+	obj := map[string]interface{}{
+		"null":   nil,
+		"empty":  map[string]interface{}{},
+		"nested": map[string]interface{}{"list": []interface{}{}, "null": nil},
+		"list": []interface{}{
+			map[string]interface{}{"name": "a", "null": nil},
+			map[string]interface{}{},
+		},
+		"string": "",
+		"number": int64(0),
+		"bool":   false,
+	}
+	expected := map[string]interface{}{
+		"list": []interface{}{
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{},
+		},
+		"string": "",
+		"number": int64(0),
+		"bool":   false,
+	}
+
+	pruneEmptyFields(obj)
+	if !reflect.DeepEqual(obj, expected) {
+		t.Errorf("expected %v, got %v", expected, obj)
+	}
+}
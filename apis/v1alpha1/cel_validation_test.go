@@ -0,0 +1,206 @@
+package v1alpha1
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel/model"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"sigs.k8s.io/yaml"
+)
+
+// clusterCRDPath is the CRD generated from the types of this package with
+//
+//	controller-gen crd paths=./apis/... output:crd:dir=config/crd/bases
+const clusterCRDPath = "../../config/crd/bases/multicluster.x-k8s.io_clusters.yaml"
+
+var xValidationMarker = regexp.MustCompile(`\+kubebuilder:validation:XValidation:rule="(.*)",message="(.*)"$`)
+
+func TestCRDValidationRulesUpToDate(t *testing.T) {
+	types, err := os.ReadFile("cluster_types.go")
+	if err != nil {
+		t.Fatalf("failed to read the types: %v", err)
+	}
+	var expected []string
+	for _, line := range strings.Split(string(types), "\n") {
+		if m := xValidationMarker.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			expected = append(expected, m[1]+": "+m[2])
+		}
+	}
+
+	var actual []string
+	var collect func(s *schema.Structural)
+	collect = func(s *schema.Structural) {
+		if s == nil {
+			return
+		}
+		for _, rule := range s.Extensions.XValidations {
+			actual = append(actual, rule.Rule+": "+rule.Message)
+		}
+		for _, p := range s.Properties {
+			p := p
+			collect(&p)
+		}
+		if s.Items != nil {
+			collect(s.Items)
+		}
+		if s.AdditionalProperties != nil {
+			collect(s.AdditionalProperties.Structural)
+		}
+	}
+	collect(loadClusterSchema(t))
+
+	sort.Strings(expected)
+	sort.Strings(actual)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("the CRD is out of date, regenerate it\nexpected rules %q\ngot %q", expected, actual)
+	}
+}
+
+func TestAccessObjectRefCELRules(t *testing.T) {
+	s := loadClusterSchema(t)
+
+	// Every rule on the access refs must compile.
+	refs := s.Properties["spec"].Properties["accessObjectRef"].Items
+	if refs == nil || len(refs.Extensions.XValidations) == 0 {
+		t.Fatalf("expected validation rules on the access refs")
+	}
+	results, err := cel.Compile(refs, model.SchemaDeclType(refs, false), celconfig.PerCallLimit)
+	if err != nil {
+		t.Fatalf("failed to compile the rules: %v", err)
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			t.Errorf("failed to compile rule %q: %v", refs.Extensions.XValidations[i].Rule, result.Error)
+		}
+	}
+
+	externalSecret := &ExternalSecretRef{Provider: "Vault", Path: "secret/cluster1"}
+	serviceAccount := &ServiceAccountAccessConfig{ServiceAccountName: "fleet"}
+
+	cases := []struct {
+		name             string
+		ref              AccessObjectRef
+		expectedMessages []string
+	}{
+		{
+			name: "kubeconfig with name",
+			ref:  AccessObjectRef{Type: AccessTypeKubeConfig, Resource: "secrets", Name: "kubeconfig", Namespace: "fleet"},
+		},
+		{
+			name: "token with name",
+			ref:  AccessObjectRef{Type: AccessTypeToken, Resource: "secrets", Name: "token"},
+		},
+		{
+			name: "kubeconfig with external secret",
+			ref:  AccessObjectRef{Type: AccessTypeKubeConfig, ExternalSecretRef: externalSecret},
+		},
+		{
+			name:             "kubeconfig with name and external secret",
+			ref:              AccessObjectRef{Type: AccessTypeKubeConfig, Name: "kubeconfig", ExternalSecretRef: externalSecret},
+			expectedMessages: []string{"exactly one of externalSecretRef or name/namespace must be set"},
+		},
+		{
+			name:             "kubeconfig with namespace and external secret",
+			ref:              AccessObjectRef{Type: AccessTypeKubeConfig, Namespace: "fleet", ExternalSecretRef: externalSecret},
+			expectedMessages: []string{"exactly one of externalSecretRef or name/namespace must be set"},
+		},
+		{
+			name:             "kubeconfig without name or external secret",
+			ref:              AccessObjectRef{Type: AccessTypeKubeConfig, Resource: "secrets"},
+			expectedMessages: []string{"exactly one of externalSecretRef or name/namespace must be set"},
+		},
+		{
+			name: "kubeconfig with service account",
+			ref:  AccessObjectRef{Type: AccessTypeKubeConfig, Name: "kubeconfig", ServiceAccountRef: serviceAccount},
+			expectedMessages: []string{
+				"serviceAccountRef must be set if and only if the type is SERVICEACCOUNT",
+			},
+		},
+		{
+			name: "service account",
+			ref:  AccessObjectRef{Type: AccessTypeServiceAccount, ServiceAccountRef: serviceAccount},
+		},
+		{
+			name: "service account without service account ref",
+			ref:  AccessObjectRef{Type: AccessTypeServiceAccount},
+			expectedMessages: []string{
+				"serviceAccountRef must be set if and only if the type is SERVICEACCOUNT",
+			},
+		},
+		{
+			name: "service account with name",
+			ref:  AccessObjectRef{Type: AccessTypeServiceAccount, Name: "token", ServiceAccountRef: serviceAccount},
+			expectedMessages: []string{
+				"name and externalSecretRef may not be set for type SERVICEACCOUNT",
+			},
+		},
+		{
+			name: "service account with external secret",
+			ref:  AccessObjectRef{Type: AccessTypeServiceAccount, ServiceAccountRef: serviceAccount, ExternalSecretRef: externalSecret},
+			expectedMessages: []string{
+				"name and externalSecretRef may not be set for type SERVICEACCOUNT",
+			},
+		},
+	}
+
+	validator := cel.NewValidator(s, true, celconfig.PerCallLimit)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.Spec.AccessObjectRefs = []AccessObjectRef{c.ref}
+			obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&cluster)
+			if err != nil {
+				t.Fatalf("failed to convert the cluster: %v", err)
+			}
+
+			errs, _ := validator.Validate(context.TODO(), field.NewPath("root"), s, obj, nil, celconfig.RuntimeCELCostBudget)
+			var messages []string
+			for _, err := range errs {
+				if err.Type != field.ErrorTypeInvalid {
+					t.Errorf("unexpected error: %v", err)
+					continue
+				}
+				messages = append(messages, err.Detail)
+			}
+			assertStrings(t, messages, c.expectedMessages)
+		})
+	}
+}
+
+// loadClusterSchema returns the structural schema of the generated Cluster CRD.
+func loadClusterSchema(t *testing.T) *schema.Structural {
+	t.Helper()
+	data, err := os.ReadFile(clusterCRDPath)
+	if err != nil {
+		t.Fatalf("failed to read the CRD: %v", err)
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(data, crd); err != nil {
+		t.Fatalf("failed to decode the CRD: %v", err)
+	}
+	if len(crd.Spec.Versions) != 1 || crd.Spec.Versions[0].Schema == nil {
+		t.Fatalf("expected a single version with a schema")
+	}
+
+	props := &apiextensions.JSONSchemaProps{}
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(crd.Spec.Versions[0].Schema.OpenAPIV3Schema, props, nil); err != nil {
+		t.Fatalf("failed to convert the schema: %v", err)
+	}
+	s, err := schema.NewStructural(props)
+	if err != nil {
+		t.Fatalf("failed to build the structural schema: %v", err)
+	}
+	return s
+}
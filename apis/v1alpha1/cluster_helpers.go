@@ -0,0 +1,270 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+)
+
+// IsJoined returns true if the Joined condition of the cluster is True.
+func (c *Cluster) IsJoined() bool {
+	return meta.IsStatusConditionTrue(c.Status.Conditions, ClusterConditionJoined)
+}
+
+// IsHealthy returns true if the Healthy condition of the cluster is True.
+func (c *Cluster) IsHealthy() bool {
+	return meta.IsStatusConditionTrue(c.Status.Conditions, ClusterConditionHealthy)
+}
+
+// Phase returns the phase of the cluster: Pending until its Joined condition is True,
+// then Ready, Unhealthy or Unknown following the status of its Healthy condition. A
+// missing Healthy condition is Unknown.
+func (c *Cluster) Phase() ClusterPhase {
+	if !c.IsJoined() {
+		return ClusterPhasePending
+	}
+	switch c.ConditionStatus(ClusterConditionHealthy) {
+	case metav1.ConditionTrue:
+		return ClusterPhaseReady
+	case metav1.ConditionFalse:
+		return ClusterPhaseUnhealthy
+	}
+	return ClusterPhaseUnknown
+}
+
+// GetProperty returns the value of the named property and whether it is present.
+func (c *Cluster) GetProperty(name string) (string, bool) {
+	for _, p := range c.Status.Properties {
+		if p.Name == name {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+// ConditionStatus returns the status of the condition with the given type, or Unknown
+// if the cluster has no such condition.
+func (c *Cluster) ConditionStatus(t string) metav1.ConditionStatus {
+	condition := meta.FindStatusCondition(c.Status.Conditions, t)
+	if condition == nil {
+		return metav1.ConditionUnknown
+	}
+	return condition.Status
+}
+
+// ConditionReason returns the reason of the condition with the given type, or an empty
+// string if the cluster has no such condition.
+func (c *Cluster) ConditionReason(t string) string {
+	condition := meta.FindStatusCondition(c.Status.Conditions, t)
+	if condition == nil {
+		return ""
+	}
+	return condition.Reason
+}
+
+// GatesSatisfied returns true if the conditions of all readiness gates of the cluster
+// are True. A missing gate condition is not satisfied.
+func (c *Cluster) GatesSatisfied() bool {
+	for _, gate := range c.Spec.ReadinessGates {
+		if !meta.IsStatusConditionTrue(c.Status.Conditions, gate) {
+			return false
+		}
+	}
+	return true
+}
+
+// Age returns the time elapsed at now since the cluster was created.
+func (c *Cluster) Age(now time.Time) time.Duration {
+	return now.Sub(c.CreationTimestamp.Time)
+}
+
+// TimeToJoin returns the time from the creation of the cluster to the last transition
+// of its Joined condition. It returns false if the cluster has not joined.
+func (c *Cluster) TimeToJoin() (time.Duration, bool) {
+	joined := meta.FindStatusCondition(c.Status.Conditions, ClusterConditionJoined)
+	if joined == nil || joined.Status != metav1.ConditionTrue {
+		return 0, false
+	}
+	return joined.LastTransitionTime.Sub(c.CreationTimestamp.Time), true
+}
+
+// NeedsRejoin returns true if the cluster has joined but can no longer be accessed,
+// either because it has no access refs or because one of its conditions reports an
+// invalid kubeconfig.
+func NeedsRejoin(c *Cluster) bool {
+	if !c.IsJoined() {
+		return false
+	}
+	if len(c.Spec.AccessObjectRefs) == 0 {
+		return true
+	}
+	for _, condition := range c.Status.Conditions {
+		if condition.Reason == ConditionReasonKubeConfigInvalid {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInGracefulShutdown returns true if the cluster is deleted and its graceful shutdown
+// period has not elapsed yet at now.
+func IsInGracefulShutdown(cluster Cluster, now time.Time) bool {
+	if cluster.DeletionTimestamp == nil {
+		return false
+	}
+	deadline := cluster.DeletionTimestamp.Add(time.Duration(cluster.Spec.GracefulShutdownSeconds) * time.Second)
+	return deadline.After(now)
+}
+
+// ConditionSummary returns the conditions of the cluster in a compact form such as
+// "Healthy=False,Joined=True", sorted by type.
+func (c *Cluster) ConditionSummary() string {
+	return c.ConditionSummaryIncludeMissing()
+}
+
+// ConditionSummaryIncludeMissing is like ConditionSummary but also lists the given
+// condition types missing from the cluster, with an Unknown status.
+func (c *Cluster) ConditionSummaryIncludeMissing(conditionTypes ...string) string {
+	statuses := map[string]metav1.ConditionStatus{}
+	for _, t := range conditionTypes {
+		statuses[t] = metav1.ConditionUnknown
+	}
+	for _, condition := range c.Status.Conditions {
+		statuses[condition.Type] = condition.Status
+	}
+
+	types := make([]string, 0, len(statuses))
+	for t := range statuses {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	parts := make([]string, 0, len(types))
+	for _, t := range types {
+		parts = append(parts, fmt.Sprintf("%s=%s", t, statuses[t]))
+	}
+	return strings.Join(parts, ",")
+}
+
+// AddClusterFinalizer adds the ClusterCleanupFinalizer to the cluster. It returns true
+// if the finalizer was added and false if it was already present.
+func AddClusterFinalizer(cluster *Cluster) bool {
+	if HasClusterFinalizer(*cluster) {
+		return false
+	}
+	cluster.Finalizers = append(cluster.Finalizers, ClusterCleanupFinalizer)
+	return true
+}
+
+// RemoveClusterFinalizer removes the ClusterCleanupFinalizer from the cluster. It
+// returns true if the finalizer was removed and false if it was absent.
+func RemoveClusterFinalizer(cluster *Cluster) bool {
+	removed := false
+	finalizers := make([]string, 0, len(cluster.Finalizers))
+	for _, f := range cluster.Finalizers {
+		if f == ClusterCleanupFinalizer {
+			removed = true
+			continue
+		}
+		finalizers = append(finalizers, f)
+	}
+	if removed {
+		cluster.Finalizers = finalizers
+	}
+	return removed
+}
+
+// HasClusterFinalizer returns true if the cluster has the ClusterCleanupFinalizer.
+func HasClusterFinalizer(cluster Cluster) bool {
+	for _, f := range cluster.Finalizers {
+		if f == ClusterCleanupFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// OwnerReference returns an owner reference to the cluster for objects created on its
+// behalf. Controller and BlockOwnerDeletion are both set to controller.
+func (c *Cluster) OwnerReference(controller bool) metav1.OwnerReference {
+	blockOwnerDeletion := controller
+	return metav1.OwnerReference{
+		APIVersion:         GroupVersion.String(),
+		Kind:               "Cluster",
+		Name:               c.Name,
+		UID:                c.UID,
+		Controller:         &controller,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	}
+}
+
+// ResetStatus clears the status of the cluster, including its conditions, resources
+// and properties, so that it can be derived again from scratch. The metadata and spec
+// of the cluster are left untouched.
+func (c *Cluster) ResetStatus() {
+	c.Status = ClusterStatus{}
+}
+
+// ResetConditionsOnly clears the conditions of the cluster and leaves the rest of the
+// status untouched.
+func (c *Cluster) ResetConditionsOnly() {
+	c.Status.Conditions = nil
+}
+
+// KubernetesVersionAtLeast returns true if the Kubernetes version of the cluster is at
+// least min. Both versions may have a leading "v", and pre-release or vendor suffixes
+// such as "-eks-1" or "+k3s1" are ignored, so v1.28.2+k3s1 is at least 1.28. An error
+// is returned if either version cannot be parsed.
+func (c *Cluster) KubernetesVersionAtLeast(min string) (bool, error) {
+	minVersion, err := utilversion.ParseGeneric(min)
+	if err != nil {
+		return false, fmt.Errorf("invalid minimum version: %w", err)
+	}
+	v, err := utilversion.ParseGeneric(c.Status.Version.Kubernetes)
+	if err != nil {
+		return false, fmt.Errorf("invalid kubernetes version of cluster %q: %w", c.Name, err)
+	}
+	return v.AtLeast(minVersion), nil
+}
+
+// String returns a one-line summary of the cluster for logs and test failures: its
+// namespaced name, its phase, its Kubernetes
+// version, its CPU and memory capacity and its number of taints. Unknown values are
+// printed as "-". A nil cluster is printed as "<nil>".
+func (c *Cluster) String() string {
+	if c == nil {
+		return "<nil>"
+	}
+
+	name := c.Name
+	if c.Namespace != "" {
+		name = c.Namespace + "/" + c.Name
+	}
+	return fmt.Sprintf("Cluster(%s phase=%s version=%s cpu=%s memory=%s taints=%d)",
+		name,
+		c.Phase(),
+		orDash(c.Status.Version.Kubernetes),
+		capacityString(c.Status.Resources.Capacity, ResourceCPU),
+		capacityString(c.Status.Resources.Capacity, ResourceMemory),
+		len(c.Spec.Taints))
+}
+
+func capacityString(r ResourceList, name ResourceName) string {
+	q, ok := r[name]
+	if !ok {
+		return "-"
+	}
+	return q.String()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,458 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestConditionStatusAndReason(t *testing.T) {
+	cluster := newCluster("cluster1",
+		metav1.Condition{Type: ClusterConditionJoined, Status: metav1.ConditionTrue, Reason: "Joined"},
+		metav1.Condition{Type: "example.com/Custom", Status: metav1.ConditionFalse, Reason: "NotReady"},
+	)
+
+	cases := []struct {
+		conditionType  string
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{conditionType: ClusterConditionJoined, expectedStatus: metav1.ConditionTrue, expectedReason: "Joined"},
+		{conditionType: "example.com/Custom", expectedStatus: metav1.ConditionFalse, expectedReason: "NotReady"},
+		{conditionType: ClusterConditionHealthy, expectedStatus: metav1.ConditionUnknown},
+	}
+
+	for _, c := range cases {
+		t.Run(c.conditionType, func(t *testing.T) {
+			if status := cluster.ConditionStatus(c.conditionType); status != c.expectedStatus {
+				t.Errorf("expected status %q, got %q", c.expectedStatus, status)
+			}
+			if reason := cluster.ConditionReason(c.conditionType); reason != c.expectedReason {
+				t.Errorf("expected reason %q, got %q", c.expectedReason, reason)
+			}
+		})
+	}
+}
+
+func TestPhase(t *testing.T) {
+	cases := []struct {
+		name     string
+		joined   metav1.ConditionStatus
+		healthy  metav1.ConditionStatus
+		expected ClusterPhase
+	}{
+		{name: "no conditions", expected: ClusterPhasePending},
+		{name: "not joined", joined: metav1.ConditionFalse, healthy: metav1.ConditionTrue, expected: ClusterPhasePending},
+		{name: "joined unknown", joined: metav1.ConditionUnknown, expected: ClusterPhasePending},
+		{name: "joined without health", joined: metav1.ConditionTrue, expected: ClusterPhaseUnknown},
+		{name: "healthy", joined: metav1.ConditionTrue, healthy: metav1.ConditionTrue, expected: ClusterPhaseReady},
+		{name: "unhealthy", joined: metav1.ConditionTrue, healthy: metav1.ConditionFalse, expected: ClusterPhaseUnhealthy},
+		{name: "health unknown", joined: metav1.ConditionTrue, healthy: metav1.ConditionUnknown, expected: ClusterPhaseUnknown},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			if c.joined != "" {
+				cluster.Status.Conditions = append(cluster.Status.Conditions, newCondition(ClusterConditionJoined, c.joined))
+			}
+			if c.healthy != "" {
+				cluster.Status.Conditions = append(cluster.Status.Conditions, newCondition(ClusterConditionHealthy, c.healthy))
+			}
+			if phase := cluster.Phase(); phase != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, phase)
+			}
+		})
+	}
+}
+
+func TestNeedsRejoin(t *testing.T) {
+	joined := newCondition(ClusterConditionJoined, metav1.ConditionTrue)
+	kubeConfigInvalid := metav1.Condition{Type: ClusterConditionHealthy, Status: metav1.ConditionFalse, Reason: ConditionReasonKubeConfigInvalid}
+	refs := []AccessObjectRef{{Type: AccessTypeKubeConfig, Resource: "secrets", Name: "kubeconfig"}}
+
+	cases := []struct {
+		name       string
+		conditions []metav1.Condition
+		refs       []AccessObjectRef
+		expected   bool
+	}{
+		{name: "not joined", refs: nil},
+		{name: "not joined with invalid kubeconfig", conditions: []metav1.Condition{kubeConfigInvalid}, refs: refs},
+		{name: "joined with access refs", conditions: []metav1.Condition{joined}, refs: refs},
+		{name: "joined without access refs", conditions: []metav1.Condition{joined}, expected: true},
+		{name: "joined with invalid kubeconfig", conditions: []metav1.Condition{joined, kubeConfigInvalid}, refs: refs, expected: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1", c.conditions...)
+			cluster.Spec.AccessObjectRefs = c.refs
+			if actual := NeedsRejoin(&cluster); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIsInGracefulShutdown(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	deletedAt := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(-d))
+		return &t
+	}
+
+	cases := []struct {
+		name                    string
+		deletionTimestamp       *metav1.Time
+		gracefulShutdownSeconds int32
+		expected                bool
+	}{
+		{name: "not deleted", gracefulShutdownSeconds: 600},
+		{name: "deleted within the window", deletionTimestamp: deletedAt(5 * time.Minute), gracefulShutdownSeconds: 600, expected: true},
+		{name: "deleted past the window", deletionTimestamp: deletedAt(15 * time.Minute), gracefulShutdownSeconds: 600},
+		{name: "deleted at the end of the window", deletionTimestamp: deletedAt(10 * time.Minute), gracefulShutdownSeconds: 600},
+		{name: "deleted without a window", deletionTimestamp: deletedAt(0)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.DeletionTimestamp = c.deletionTimestamp
+			cluster.Spec.GracefulShutdownSeconds = c.gracefulShutdownSeconds
+			if actual := IsInGracefulShutdown(cluster, now); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestConditionSummary(t *testing.T) {
+	cluster := newCluster("cluster1",
+		newCondition(ClusterConditionJoined, metav1.ConditionTrue),
+		newCondition(ClusterConditionHealthy, metav1.ConditionFalse),
+	)
+
+	cases := []struct {
+		name     string
+		cluster  Cluster
+		missing  []string
+		expected string
+	}{
+		{
+			name:     "no conditions",
+			cluster:  newCluster("cluster1"),
+			expected: "",
+		},
+		{
+			name:     "sorted by type",
+			cluster:  cluster,
+			expected: "Healthy=False,Joined=True",
+		},
+		{
+			name:     "missing conditions included",
+			cluster:  cluster,
+			missing:  []string{"Degraded", ClusterConditionJoined},
+			expected: "Degraded=Unknown,Healthy=False,Joined=True",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual := c.cluster.ConditionSummaryIncludeMissing(c.missing...)
+			if actual != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, actual)
+			}
+			if len(c.missing) == 0 && c.cluster.ConditionSummary() != actual {
+				t.Errorf("expected ConditionSummary to omit missing conditions, got %q", c.cluster.ConditionSummary())
+			}
+		})
+	}
+}
+
+func TestClusterFinalizer(t *testing.T) {
+	cluster := newCluster("cluster1")
+	cluster.Finalizers = []string{"example.com/other"}
+
+	if HasClusterFinalizer(cluster) {
+		t.Fatalf("expected no cleanup finalizer, got %v", cluster.Finalizers)
+	}
+	if !AddClusterFinalizer(&cluster) {
+		t.Errorf("expected the finalizer to be added")
+	}
+	if AddClusterFinalizer(&cluster) {
+		t.Errorf("expected adding the finalizer again to be a no-op")
+	}
+	assertStrings(t, cluster.Finalizers, []string{"example.com/other", ClusterCleanupFinalizer})
+	if !HasClusterFinalizer(cluster) {
+		t.Errorf("expected the cleanup finalizer, got %v", cluster.Finalizers)
+	}
+
+	if !RemoveClusterFinalizer(&cluster) {
+		t.Errorf("expected the finalizer to be removed")
+	}
+	if RemoveClusterFinalizer(&cluster) {
+		t.Errorf("expected removing an absent finalizer to be a no-op")
+	}
+	assertStrings(t, cluster.Finalizers, []string{"example.com/other"})
+}
+
+func TestAddClusterFinalizerNoFinalizers(t *testing.T) {
+	cluster := newCluster("cluster1")
+	if !AddClusterFinalizer(&cluster) {
+		t.Errorf("expected the finalizer to be added")
+	}
+	assertStrings(t, cluster.Finalizers, []string{ClusterCleanupFinalizer})
+}
+
+func TestOwnerReference(t *testing.T) {
+	cluster := newCluster("cluster1")
+	cluster.UID = types.UID("6a4f2c2e-8f4b-4c4e-9a38-8f1d1d6b2b4e")
+
+	for _, controller := range []bool{true, false} {
+		ref := cluster.OwnerReference(controller)
+		if ref.APIVersion != "multicluster.x-k8s.io/v1alpha1" || ref.APIVersion != GroupVersion.String() {
+			t.Errorf("expected the registered group version, got %q", ref.APIVersion)
+		}
+		if ref.Kind != "Cluster" || ref.Name != cluster.Name || ref.UID != cluster.UID {
+			t.Errorf("expected a reference to the cluster, got %v", ref)
+		}
+		if *ref.Controller != controller || *ref.BlockOwnerDeletion != controller {
+			t.Errorf("expected controller and blockOwnerDeletion %v, got %v and %v", controller, *ref.Controller, *ref.BlockOwnerDeletion)
+		}
+	}
+}
+
+func TestResetStatus(t *testing.T) {
+	newStatusCluster := func() Cluster {
+		cluster := newCluster("cluster1", newCondition(ClusterConditionJoined, metav1.ConditionTrue))
+		cluster.Labels = map[string]string{"env": "prod"}
+		cluster.Spec.Taints = []Taint{{Key: "key", Effect: TaintEffectNoSelect}}
+		cluster.Status.Properties = []Property{{Name: PropertyClusterID, Value: "id"}}
+		cluster.Status.ProviderID = "aws:///us-east-1a/i-1"
+		return cluster
+	}
+
+	cases := []struct {
+		name               string
+		reset              func(c *Cluster)
+		expectedProperties int
+	}{
+		{
+			name:  "reset status",
+			reset: (*Cluster).ResetStatus,
+		},
+		{
+			name:               "reset conditions only",
+			reset:              (*Cluster).ResetConditionsOnly,
+			expectedProperties: 1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newStatusCluster()
+			c.reset(&cluster)
+
+			if len(cluster.Status.Conditions) != 0 {
+				t.Errorf("expected no conditions, got %v", cluster.Status.Conditions)
+			}
+			if len(cluster.Status.Properties) != c.expectedProperties {
+				t.Errorf("expected %d properties, got %v", c.expectedProperties, cluster.Status.Properties)
+			}
+			if cluster.Name != "cluster1" || cluster.Labels["env"] != "prod" || len(cluster.Spec.Taints) != 1 {
+				t.Errorf("expected metadata and spec to be untouched, got %v and %v", cluster.ObjectMeta, cluster.Spec)
+			}
+		})
+	}
+}
+
+func TestGatesSatisfied(t *testing.T) {
+	gates := []string{"NetworkReady", "MonitoringReady"}
+
+	cases := []struct {
+		name       string
+		gates      []string
+		conditions []metav1.Condition
+		expected   bool
+	}{
+		{
+			name:     "no gates",
+			expected: true,
+		},
+		{
+			name:  "all gates true",
+			gates: gates,
+			conditions: []metav1.Condition{
+				newCondition("NetworkReady", metav1.ConditionTrue),
+				newCondition("MonitoringReady", metav1.ConditionTrue),
+			},
+			expected: true,
+		},
+		{
+			name:  "gate false",
+			gates: gates,
+			conditions: []metav1.Condition{
+				newCondition("NetworkReady", metav1.ConditionTrue),
+				newCondition("MonitoringReady", metav1.ConditionFalse),
+			},
+		},
+		{
+			name:  "gate unknown",
+			gates: gates,
+			conditions: []metav1.Condition{
+				newCondition("NetworkReady", metav1.ConditionUnknown),
+				newCondition("MonitoringReady", metav1.ConditionTrue),
+			},
+		},
+		{
+			name:       "gate missing",
+			gates:      gates,
+			conditions: []metav1.Condition{newCondition("NetworkReady", metav1.ConditionTrue)},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1", c.conditions...)
+			cluster.Spec.ReadinessGates = c.gates
+			if actual := cluster.GatesSatisfied(); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAgeAndTimeToJoin(t *testing.T) {
+	created := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	joinedAt := created.Add(5 * time.Minute)
+	joinedCondition := func(status metav1.ConditionStatus) metav1.Condition {
+		c := newCondition(ClusterConditionJoined, status)
+		c.LastTransitionTime = metav1.NewTime(joinedAt)
+		return c
+	}
+
+	cases := []struct {
+		name          string
+		conditions    []metav1.Condition
+		expected      time.Duration
+		expectedFound bool
+	}{
+		{
+			name:          "joined",
+			conditions:    []metav1.Condition{joinedCondition(metav1.ConditionTrue)},
+			expected:      5 * time.Minute,
+			expectedFound: true,
+		},
+		{
+			name:       "not joined",
+			conditions: []metav1.Condition{joinedCondition(metav1.ConditionFalse)},
+		},
+		{
+			name: "no joined condition",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1", c.conditions...)
+			cluster.CreationTimestamp = metav1.NewTime(created)
+
+			if age := cluster.Age(created.Add(time.Hour)); age != time.Hour {
+				t.Errorf("expected age %v, got %v", time.Hour, age)
+			}
+			d, found := cluster.TimeToJoin()
+			if d != c.expected || found != c.expectedFound {
+				t.Errorf("expected (%v, %v), got (%v, %v)", c.expected, c.expectedFound, d, found)
+			}
+		})
+	}
+}
+
+func TestKubernetesVersionAtLeast(t *testing.T) {
+	cases := []struct {
+		name        string
+		version     string
+		min         string
+		expected    bool
+		expectError bool
+	}{
+		{name: "equal", version: "v1.28.0", min: "1.28.0", expected: true},
+		{name: "newer patch", version: "v1.28.2", min: "v1.28", expected: true},
+		{name: "newer minor", version: "1.29.0", min: "1.28", expected: true},
+		{name: "older minor", version: "v1.27.9", min: "1.28"},
+		{name: "vendor suffix", version: "v1.28.2+k3s1", min: "1.28", expected: true},
+		{name: "pre-release suffix", version: "v1.28.3-eks-1", min: "1.28.3", expected: true},
+		{name: "empty version", min: "1.28", expectError: true},
+		{name: "invalid version", version: "latest", min: "1.28", expectError: true},
+		{name: "invalid minimum", version: "v1.28.0", min: "one", expectError: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.Status.Version.Kubernetes = c.version
+
+			actual, err := cluster.KubernetesVersionAtLeast(c.min)
+			if c.expectError != (err != nil) {
+				t.Fatalf("expected error %v, got %v", c.expectError, err)
+			}
+			if actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	representative := newCluster("cluster1",
+		newCondition(ClusterConditionJoined, metav1.ConditionTrue),
+		newCondition(ClusterConditionHealthy, metav1.ConditionFalse))
+	representative.Namespace = "fleet"
+	representative.Status.Version.Kubernetes = "v1.28.0"
+	representative.Status.Resources.Capacity = ResourceList{
+		ResourceCPU:    resource.MustParse("8"),
+		ResourceMemory: resource.MustParse("32Gi"),
+	}
+	representative.Spec.Taints = []Taint{
+		{Key: "example.com/maintenance", Effect: TaintEffectNoSelect},
+		{Key: "example.com/spot", Effect: TaintEffectPreferNoSelect},
+	}
+	empty := newCluster("cluster2")
+
+	cases := []struct {
+		name     string
+		cluster  *Cluster
+		expected string
+	}{
+		{
+			name:     "representative cluster",
+			cluster:  &representative,
+			expected: "Cluster(fleet/cluster1 phase=Unhealthy version=v1.28.0 cpu=8 memory=32Gi taints=2)",
+		},
+		{
+			name:     "empty cluster",
+			cluster:  &empty,
+			expected: "Cluster(cluster2 phase=Pending version=- cpu=- memory=- taints=0)",
+		},
+		{
+			name:     "nil cluster",
+			expected: "<nil>",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := c.cluster.String(); actual != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, actual)
+			}
+			if actual := fmt.Sprintf("%v", c.cluster); actual != c.expected {
+				t.Errorf("expected formatting to use String %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
@@ -8,26 +8,149 @@ import (
 type ClusterSpec struct {
 	// AccessObjectRefs represents references to objects providing access info to the cluster.
 	// It could be a kubeconf stored in a secret
+	// The list is atomic and replaced as a whole by patches: service account and external
+	// secret refs have no name, so no field identifies every ref, and the order of the
+	// refs is their failover priority.
+	// +listType=atomic
 	AccessObjectRefs []AccessObjectRef `json:"accessObjectRef,omitempty"`
 
 	// HealthProbe is used to coordinate the heartbeat time of to check the healthiness of the cluster.
 	HealthProbe HealthProbe `json:"healthProbe"`
 
 	// Taints is a property of cluster that allow the cluster to be repelled when scheduling.
+	// Server-side apply merges taints by key and effect. Strategic merge patch only
+	// supports a single merge key, so it merges taints by key: a patched taint replaces
+	// every taint with the same key, whatever its effect.
+	// +listType=map
+	// +listMapKey=key
+	// +listMapKey=effect
+	// +patchMergeKey=key
+	// +patchStrategy=merge
 	// +optional
-	Taints []Taint `json:"taints,omitempty"`
+	Taints []Taint `json:"taints,omitempty" patchStrategy:"merge" patchMergeKey:"key"`
+
+	// GracefulShutdownSeconds is the time given to migrate workloads away from the
+	// cluster after it is deleted, before its access is revoked.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=86400
+	// +optional
+	GracefulShutdownSeconds int32 `json:"gracefulShutdownSeconds,omitempty"`
+
+	// ReadinessGates lists condition types that must all be True, in addition to the
+	// built-in conditions, for the cluster to be usable. For example NetworkReady or
+	// MonitoringReady.
+	// +listType=set
+	// +optional
+	ReadinessGates []string `json:"readinessGates,omitempty"`
+
+	// MaintenanceContacts are the email addresses or http(s) URLs, such as Slack
+	// webhooks, notified when the cluster enters maintenance or becomes unavailable.
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:items:MaxLength=512
+	// +listType=atomic
+	// +optional
+	MaintenanceContacts []string `json:"maintenanceContacts,omitempty"`
+
+	// ResourceQuota caps the resources of the cluster that workloads placed on it may
+	// claim.
+	// +optional
+	ResourceQuota *ClusterResourceQuota `json:"resourceQuota,omitempty"`
+
+	// FailoverStrategy selects the access ref tried next when accessing the cluster with
+	// the current one fails. Empty means None.
+	// +kubebuilder:validation:Enum=RoundRobin;Priority;None
+	// +optional
+	FailoverStrategy FailoverStrategy `json:"failoverStrategy,omitempty"`
+}
+
+// FailoverStrategy selects the access ref tried after a failure.
+type FailoverStrategy string
+
+const (
+	// FailoverStrategyRoundRobin tries the access refs in turn, wrapping around after the
+	// last one.
+	FailoverStrategyRoundRobin FailoverStrategy = "RoundRobin"
+	// FailoverStrategyPriority tries the access refs in order, starting from the first
+	// one, and gives up after the last one.
+	FailoverStrategyPriority FailoverStrategy = "Priority"
+	// FailoverStrategyNone only uses the current access ref.
+	FailoverStrategyNone FailoverStrategy = "None"
+)
+
+// ClusterResourceQuota caps the resources of a cluster that workloads may claim. Unset
+// limits mean no cap.
+type ClusterResourceQuota struct {
+	// MaxCPU is the maximum CPU workloads may claim.
+	// +optional
+	MaxCPU *resource.Quantity `json:"maxCPU,omitempty"`
+
+	// MaxMemory is the maximum memory workloads may claim.
+	// +optional
+	MaxMemory *resource.Quantity `json:"maxMemory,omitempty"`
+
+	// MaxPods is the maximum number of pods workloads may run.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxPods *int32 `json:"maxPods,omitempty"`
 }
 
 type HealthProbe struct {
 	// HeartbeatIntervalSeconds is the interval of the cluster's heartbeat to check the
 	// availability of the cluster.
+	// +kubebuilder:default=60
 	HeartbeatIntervalSeconds int32 `json:"heatbeatIntervalSeconds"`
+
+	// BackoffMultiplier is the factor the probe interval is multiplied by after each
+	// consecutive failure, a decimal number between 1 and 10 such as "1.5". It is a
+	// string because API fields avoid floating point numbers.
+	// +kubebuilder:validation:Pattern=`^([1-9](\.[0-9]+)?|10(\.0+)?)$`
+	// +kubebuilder:default="1.5"
+	// +optional
+	BackoffMultiplier string `json:"backoffMultiplier,omitempty"`
+
+	// MaxBackoffIntervalSeconds caps the probe interval while backing off. Zero means
+	// the maximum of 86400 seconds.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=86400
+	// +optional
+	MaxBackoffIntervalSeconds int32 `json:"maxBackoffIntervalSeconds,omitempty"`
+
+	// Suspend pauses the health probing of the cluster. While suspended, the health of
+	// the cluster keeps its last known value, for example during planned disruptions.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// HTTPEndpoint is the URL of a health endpoint of the cluster, such as /healthz,
+	// probed over HTTP every heartbeat interval instead of relying on heartbeats.
+	// +optional
+	HTTPEndpoint string `json:"httpEndpoint,omitempty"`
+
+	// HTTPExpectedStatus is the HTTP status code returned by a healthy HTTPEndpoint.
+	// Defaults to 200 when HTTPEndpoint is set.
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	// +optional
+	HTTPExpectedStatus int32 `json:"httpExpectedStatus,omitempty"`
 }
 
+// HealthProbeType is the mechanism used to probe the health of a cluster.
+type HealthProbeType string
+
+const (
+	// HeartbeatProbe checks the health of the cluster by its heartbeats.
+	HeartbeatProbe HealthProbeType = "Heartbeat"
+	// HTTPProbe checks the health of the cluster by probing its HTTP endpoint.
+	HTTPProbe HealthProbeType = "HTTP"
+)
+
+// +kubebuilder:validation:XValidation:rule="self.type == 'SERVICEACCOUNT' || (has(self.externalSecretRef) ? (!has(self.name) || size(self.name) == 0) && (!has(self.__namespace__) || size(self.__namespace__) == 0) : has(self.name) && size(self.name) > 0)",message="exactly one of externalSecretRef or name/namespace must be set"
+// +kubebuilder:validation:XValidation:rule="(self.type == 'SERVICEACCOUNT') == has(self.serviceAccountRef)",message="serviceAccountRef must be set if and only if the type is SERVICEACCOUNT"
+// +kubebuilder:validation:XValidation:rule="self.type != 'SERVICEACCOUNT' || ((!has(self.name) || size(self.name) == 0) && !has(self.externalSecretRef))",message="name and externalSecretRef may not be set for type SERVICEACCOUNT"
 type AccessObjectRef struct {
 	// Type is type of the access info. If the type is KUBECONFIG, the realted object
-	// should be a secret containing kubeconfig key.
-	Type string `json:"type"`
+	// should be a secret containing kubeconfig key. If the type is TOKEN, the related
+	// object should be a secret containing token and ca.crt keys.
+	Type AccessType `json:"type"`
 
 	// Group is the API Group of the Kubernetes resource,
 	// empty string indicates it is in core group.
@@ -39,17 +162,166 @@ type AccessObjectRef struct {
 	// +required
 	Resource string `json:"resource"`
 
-	// Name is the name of the Kubernetes resource.
-	// +kubebuilder:validation:Required
-	// +required
+	// Name is the name of the Kubernetes resource. It is required unless the access
+	// info is stored in an external secret store.
+	// +kubebuilder:default=""
+	// +optional
 	Name string `json:"name"`
 
 	// Name is the namespace of the Kubernetes resource, empty string indicates
 	// it is a cluster scoped resource.
 	// +optional
 	Namespace string `json:"namespace"`
+
+	// TLSInsecureSkipVerify skips the verification of the server certificate when
+	// connecting to the cluster. It is only meant for clusters using self-signed
+	// certificates in development and test environments, and may be rejected
+	// depending on the policy of the webhook.
+	// +optional
+	TLSInsecureSkipVerify bool `json:"tlsInsecureSkipVerify,omitempty"`
+
+	// ServiceAccountRef configures the service account token used to access the
+	// cluster when the type is SERVICEACCOUNT.
+	// +optional
+	ServiceAccountRef *ServiceAccountAccessConfig `json:"serviceAccountRef,omitempty"`
+
+	// LastRotatedAt is the time at which the credentials of the access ref were last
+	// rotated.
+	// +optional
+	LastRotatedAt *metav1.Time `json:"lastRotatedAt,omitempty"`
+
+	// RotationIntervalDays is the number of days after which the credentials of the
+	// access ref should be rotated. Zero means the credentials are not rotated.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=365
+	// +optional
+	RotationIntervalDays int32 `json:"rotationIntervalDays,omitempty"`
+
+	// ExternalSecretRef references access info stored in an external secret store
+	// instead of a Kubernetes resource. It is mutually exclusive with Name and
+	// Namespace.
+	// +optional
+	ExternalSecretRef *ExternalSecretRef `json:"externalSecretRef,omitempty"`
+
+	// BackoffPolicy configures how connecting to the cluster with the access ref is
+	// retried after authentication failures. Nil means the default policy.
+	// +optional
+	BackoffPolicy *BackoffPolicy `json:"backoffPolicy,omitempty"`
+
+	// Annotations pass provider specific metadata to the credential provider of the
+	// access ref, such as a Vault role or an IAM role ARN. Values are at most 512 bytes.
+	// +kubebuilder:validation:MaxProperties=16
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// SecretVersionHash is the hex encoded SHA-256 hash of the resource version of the
+	// secret referenced by the access ref when its credentials were last loaded. It
+	// tells clients caching the credentials that the secret was rotated.
+	// +optional
+	SecretVersionHash string `json:"secretVersionHash,omitempty"`
 }
 
+// BackoffPolicy configures an exponential backoff between retries.
+type BackoffPolicy struct {
+	// InitialIntervalSeconds is the interval before the first retry. Defaults to 5.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialIntervalSeconds int32 `json:"initialIntervalSeconds,omitempty"`
+
+	// MaxIntervalSeconds caps the interval between retries. Defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxIntervalSeconds int32 `json:"maxIntervalSeconds,omitempty"`
+
+	// Multiplier is the factor the interval is multiplied by after each retry, a decimal
+	// number of at least 1 such as "1.5". It is a string because API fields avoid
+	// floating point numbers. Defaults to 2.
+	// +kubebuilder:validation:Pattern=`^[1-9][0-9]*(\.[0-9]+)?$`
+	// +kubebuilder:default="2"
+	// +optional
+	Multiplier string `json:"multiplier,omitempty"`
+
+	// MaxRetries is the number of retries after which retrying stops. Zero means
+	// retrying never stops.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries int32 `json:"maxRetries,omitempty"`
+}
+
+// ExternalSecretRef references a secret stored in an external secret store, such as
+// the stores supported by the External Secrets Operator.
+type ExternalSecretRef struct {
+	// Provider is the external secret store holding the secret.
+	// +kubebuilder:validation:Enum=Vault;AWS;GCP
+	// +kubebuilder:validation:Required
+	// +required
+	Provider string `json:"provider"`
+
+	// Path is the path of the secret in the store.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Path string `json:"path"`
+
+	// Key is the key of the access info within the secret. Empty means the whole
+	// secret.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+const (
+	// ExternalSecretProviderVault is the HashiCorp Vault secret store.
+	ExternalSecretProviderVault = "Vault"
+	// ExternalSecretProviderAWS is the AWS Secrets Manager secret store.
+	ExternalSecretProviderAWS = "AWS"
+	// ExternalSecretProviderGCP is the GCP Secret Manager secret store.
+	ExternalSecretProviderGCP = "GCP"
+)
+
+// ServiceAccountAccessConfig configures access to a cluster with a projected service
+// account token.
+type ServiceAccountAccessConfig struct {
+	// ServiceAccountName is the name of the service account to request a token for.
+	// +kubebuilder:validation:Required
+	// +required
+	ServiceAccountName string `json:"serviceAccountName"`
+
+	// TokenExpirationSeconds is the requested lifetime of the token. The token issuer
+	// may return a token with a different lifetime.
+	// +kubebuilder:validation:Minimum=600
+	// +optional
+	TokenExpirationSeconds *int64 `json:"tokenExpirationSeconds,omitempty"`
+
+	// Audiences are the intended audiences of the token. Empty means the audiences of
+	// the API server issuing the token.
+	// +listType=atomic
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+// AccessType is the type of the access info of a cluster.
+type AccessType string
+
+const (
+	// AccessTypeKubeConfig means the access info is a kubeconfig stored in a secret.
+	AccessTypeKubeConfig AccessType = "KUBECONFIG"
+	// AccessTypeToken means the access info is a bearer token and a CA bundle stored in
+	// a secret.
+	AccessTypeToken AccessType = "TOKEN"
+	// AccessTypeServiceAccount means the access info is a projected service account
+	// token configured by the ServiceAccountRef of the access ref.
+	AccessTypeServiceAccount AccessType = "SERVICEACCOUNT"
+)
+
+const (
+	// KubeConfigSecretKey is the key of the kubeconfig in a KUBECONFIG access secret.
+	KubeConfigSecretKey = "kubeconfig"
+	// TokenSecretKey is the key of the bearer token in a TOKEN access secret.
+	TokenSecretKey = "token"
+	// CASecretKey is the key of the CA bundle in a TOKEN access secret.
+	CASecretKey = "ca.crt"
+)
+
 // The managed cluster this Taint is attached to has the "effect" on
 // any placement that does not tolerate the Taint.
 type Taint struct {
@@ -74,6 +346,11 @@ type Taint struct {
 	// +nullable
 	// +required
 	TimeAdded metav1.Time `json:"timeAdded"`
+	// TTLSeconds is the number of seconds after TimeAdded at which the taint expires
+	// and is removed from the cluster. Nil means the taint never expires.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSeconds *int32 `json:"ttlSeconds,omitempty"`
 }
 
 type TaintEffect string
@@ -92,6 +369,19 @@ const (
 	TaintEffectNoSelectIfNew TaintEffect = "NoSelectIfNew"
 )
 
+const (
+	// ManagedTaintPrefix is the key prefix of taints managed by the cluster inventory
+	// controllers. Taints with other keys are user defined and never touched by them.
+	ManagedTaintPrefix = "managed.cluster.x-k8s.io/"
+
+	// TaintClusterNotReady is added to a cluster whose Healthy condition is False.
+	TaintClusterNotReady = ManagedTaintPrefix + "not-ready"
+
+	// CapacityPressureTaintPrefix is the key prefix of the taints added to a cluster
+	// running low on a resource.
+	CapacityPressureTaintPrefix = "cluster.inventory/"
+)
+
 type ClusterStatus struct {
 	// Conditions contains the different condition statuses for this cluster.
 	Conditions []metav1.Condition `json:"conditions"`
@@ -102,19 +392,225 @@ type ClusterStatus struct {
 	// Resource represents the resource of the cluster.
 	Resources Resources `json:"resources,omitempty"`
 
+	// ResourcesHistory keeps the most recent snapshots of Resources, oldest first.
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	ResourcesHistory []ResourceSnapshot `json:"resourcesHistory,omitempty"`
+
 	// Properties represents properties of collected from the cluster,
 	// for example a unique cluster identifier (id.k8s.io).
 	// The set of properties is not uniform across a fleet, some properties can be
 	// vendor or version specific and may not be included from all clusters.
+	// Property names are unique within the list.
+	// +listType=map
+	// +listMapKey=name
 	// +optional
 	Properties []Property `json:"properties,omitempty"`
+
+	// ProviderID identifies the infrastructure of the cluster at its cloud provider, in
+	// the format used by the ProviderID of nodes, for example
+	// aws:///us-east-1a/i-1234567890abcdef0.
+	// +optional
+	ProviderID string `json:"providerID,omitempty"`
+
+	// APIServerURL is the URL of the API server of the cluster.
+	// +optional
+	APIServerURL string `json:"apiServerURL,omitempty"`
+
+	// ControlPlaneEndpoint is the URL of the control plane of the cluster, populated
+	// when the cluster joins, so that the cluster can be located without reading its
+	// access secrets.
+	// +optional
+	ControlPlaneEndpoint string `json:"controlPlaneEndpoint,omitempty"`
+
+	// APIServerCertSANs lists the subject alternative names, DNS names or IP addresses,
+	// covered by the serving certificate of the API server.
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	APIServerCertSANs []string `json:"apiServerCertSANs,omitempty"`
+
+	// ResourceQuotaUsage summarizes the resource quotas of the namespaces of the
+	// cluster.
+	// +kubebuilder:validation:MaxItems=256
+	// +optional
+	ResourceQuotaUsage []ResourceQuotaSummary `json:"resourceQuotaUsage,omitempty"`
+
+	// Namespaces lists the namespaces of the cluster that are managed or relevant for
+	// routing tenants. The list is kept sorted, see SortNamespaces.
+	// +kubebuilder:validation:MaxItems=512
+	// +listType=set
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Disconnected preserves the last known good state of the cluster while it is
+	// disconnected. It is nil while the cluster is connected.
+	// +optional
+	Disconnected *DisconnectedState `json:"disconnected,omitempty"`
+
+	// ManagedNamespaceCount is the number of namespaces on the cluster.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ManagedNamespaceCount int32 `json:"managedNamespaceCount,omitempty"`
+
+	// WorkloadCount is the number of workloads on the cluster.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	WorkloadCount int32 `json:"workloadCount,omitempty"`
+
+	// AgentStatus reports the state of the agent running on the cluster.
+	// +optional
+	AgentStatus *AgentStatus `json:"agentStatus,omitempty"`
+
+	// NodeSummary aggregates the state of the nodes of the cluster.
+	// +optional
+	NodeSummary *NodeSummary `json:"nodeSummary,omitempty"`
+
+	// Migration tracks the progress of an upgrade of the cluster.
+	// +optional
+	Migration *MigrationStatus `json:"migration,omitempty"`
+
+	// ReachableFrom lists the hub zones from which the cluster is reachable. The
+	// wildcard "*" means the cluster is reachable from every zone.
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	ReachableFrom []string `json:"reachableFrom,omitempty"`
+
+	// KubeProxyMode is the mode kube-proxy runs in on the cluster.
+	// +kubebuilder:validation:Enum:=iptables;ipvs;ebpf;none;unknown
+	// +optional
+	KubeProxyMode KubeProxyMode `json:"kubeProxyMode,omitempty"`
+
+	// StorageClasses lists the names of the storage classes available on the cluster.
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	StorageClasses []string `json:"storageClasses,omitempty"`
+
+	// IngressClasses lists the names of the ingress classes available on the cluster.
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	IngressClasses []string `json:"ingressClasses,omitempty"`
+
+	// RuntimeClasses lists the names of the runtime classes available on the cluster,
+	// such as gvisor or kata-containers.
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	RuntimeClasses []string `json:"runtimeClasses,omitempty"`
+
+	// Zones lists the availability zones the cluster spans. The first zone is the
+	// primary zone of the cluster.
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:XValidation:rule="self.all(z, size(z) > 0)",message="zones must not be empty strings"
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+
+	// Etcd represents the health of the etcd cluster backing the cluster.
+	// +optional
+	Etcd *EtcdStatus `json:"etcd,omitempty"`
+
+	// CNI is the container network interface plugin used by the cluster.
+	// +optional
+	CNI CNIPlugin `json:"cni,omitempty"`
+}
+
+// CNIPlugin is the name of a container network interface plugin.
+type CNIPlugin string
+
+// These are the known CNI plugins.
+const (
+	CNICalico   CNIPlugin = "calico"
+	CNICilium   CNIPlugin = "cilium"
+	CNIFlannel  CNIPlugin = "flannel"
+	CNIWeaveNet CNIPlugin = "weave-net"
+	CNIAntrea   CNIPlugin = "antrea"
+	CNIUnknown  CNIPlugin = "unknown"
+)
+
+// EtcdStatus represents the health of an etcd cluster.
+type EtcdStatus struct {
+	// MemberCount is the number of members of the etcd cluster.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MemberCount int32 `json:"memberCount,omitempty"`
+
+	// LeaderID is the id of the current leader of the etcd cluster.
+	// +optional
+	LeaderID string `json:"leaderID,omitempty"`
+
+	// DBSizeBytes is the size of the etcd database.
+	// +optional
+	DBSizeBytes *resource.Quantity `json:"dbSizeBytes,omitempty"`
+
+	// Healthy is true if the etcd cluster reports itself healthy.
+	// +optional
+	Healthy bool `json:"healthy,omitempty"`
 }
 
+// KubeProxyMode is the networking mode of kube-proxy.
+type KubeProxyMode string
+
+const (
+	// KubeProxyModeIPTables means kube-proxy programs services with iptables.
+	KubeProxyModeIPTables KubeProxyMode = "iptables"
+	// KubeProxyModeIPVS means kube-proxy programs services with IPVS.
+	KubeProxyModeIPVS KubeProxyMode = "ipvs"
+	// KubeProxyModeEBPF means services are implemented with eBPF, usually by the CNI
+	// replacing kube-proxy.
+	KubeProxyModeEBPF KubeProxyMode = "ebpf"
+	// KubeProxyModeNone means the cluster does not run kube-proxy.
+	KubeProxyModeNone KubeProxyMode = "none"
+	// KubeProxyModeUnknown means the mode could not be determined.
+	KubeProxyModeUnknown KubeProxyMode = "unknown"
+)
+
+// MigrationStatus represents the progress of an upgrade of the cluster.
+type MigrationStatus struct {
+	// SourceKubernetesVersion is the kubernetes version the cluster is upgraded from.
+	// +optional
+	SourceKubernetesVersion string `json:"sourceKubernetesVersion,omitempty"`
+
+	// TargetKubernetesVersion is the kubernetes version the cluster is upgraded to.
+	// +optional
+	TargetKubernetesVersion string `json:"targetKubernetesVersion,omitempty"`
+
+	// StartedAt is the time at which the upgrade started.
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// CompletedAt is the time at which the upgrade succeeded or failed.
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+
+	// Phase is the phase of the upgrade.
+	// +kubebuilder:validation:Enum:=Pending;InProgress;Succeeded;Failed
+	// +optional
+	Phase MigrationPhase `json:"phase,omitempty"`
+}
+
+type MigrationPhase string
+
+const (
+	// MigrationPhasePending means the upgrade is planned but not started yet.
+	MigrationPhasePending MigrationPhase = "Pending"
+	// MigrationPhaseInProgress means the upgrade is running.
+	MigrationPhaseInProgress MigrationPhase = "InProgress"
+	// MigrationPhaseSucceeded means the upgrade completed successfully.
+	MigrationPhaseSucceeded MigrationPhase = "Succeeded"
+	// MigrationPhaseFailed means the upgrade completed with a failure.
+	MigrationPhaseFailed MigrationPhase = "Failed"
+)
+
 // ManagedClusterVersion represents version information about the cluster.
 type ClusterVersion struct {
 	// Kubernetes is the kubernetes version of managed cluster.
 	// +optional
 	Kubernetes string `json:"kubernetes,omitempty"`
+
+	// KubernetesAPIVersions lists the API group versions served by the cluster, such as
+	// apps/v1, sorted. See SetKubernetesAPIVersions.
+	// +kubebuilder:validation:MaxItems=512
+	// +listType=set
+	// +optional
+	KubernetesAPIVersions []string `json:"kubernetesAPIVersions,omitempty"`
 }
 
 type Resources struct {
@@ -124,6 +620,134 @@ type Resources struct {
 
 	// Allocatable represents the total allocatable resources on the cluster.
 	Allocatable ResourceList `json:"allocatable,omitempty"`
+
+	// Reserved represents the resources reserved for system daemons on the cluster,
+	// which are not available to workloads.
+	// +optional
+	Reserved ResourceList `json:"reserved,omitempty"`
+
+	// GPUCapacity represents the total GPU capacity on the cluster by GPU type, for
+	// example A100 or T4.
+	// +optional
+	GPUCapacity map[string]resource.Quantity `json:"gpuCapacity,omitempty"`
+
+	// GPUAllocatable represents the allocatable GPUs on the cluster by GPU type.
+	// +optional
+	GPUAllocatable map[string]resource.Quantity `json:"gpuAllocatable,omitempty"`
+
+	// PodCapacity is the maximum number of pods on the cluster, the sum of the max
+	// pods of its nodes. Pod counts are reported here and in PodAllocatable, not as the
+	// pods resource of Capacity and Allocatable.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PodCapacity int32 `json:"podCapacity,omitempty"`
+
+	// PodAllocatable is the number of pods that can still be scheduled on the cluster.
+	// It is at most PodCapacity.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PodAllocatable int32 `json:"podAllocatable,omitempty"`
+}
+
+// ResourceQuotaSummary summarizes the resource quotas of a namespace.
+type ResourceQuotaSummary struct {
+	// Namespace is the name of the namespace.
+	// +required
+	Namespace string `json:"namespace"`
+
+	// Hard is the quota enforced on the namespace for each resource.
+	// +optional
+	Hard ResourceList `json:"hard,omitempty"`
+
+	// Used is the resources used by the namespace.
+	// +optional
+	Used ResourceList `json:"used,omitempty"`
+}
+
+// AgentStatus is the state of the agent running on a cluster.
+type AgentStatus struct {
+	// AgentID is the UUID of the agent.
+	// +optional
+	AgentID string `json:"agentID,omitempty"`
+
+	// AgentPodName is the name of the pod running the agent.
+	// +optional
+	AgentPodName string `json:"agentPodName,omitempty"`
+
+	// AgentVersion is the version of the agent.
+	// +optional
+	AgentVersion string `json:"agentVersion,omitempty"`
+
+	// LastHeartbeatTime is the time of the last heartbeat of the agent.
+	// +optional
+	LastHeartbeatTime *metav1.Time `json:"lastHeartbeatTime,omitempty"`
+
+	// LastSuccessfulHeartbeatTime is the time of the last successful heartbeat of the
+	// agent.
+	// +optional
+	LastSuccessfulHeartbeatTime *metav1.Time `json:"lastSuccessfulHeartbeatTime,omitempty"`
+
+	// HeartbeatFailureCount is the number of consecutive failed heartbeats.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	HeartbeatFailureCount int32 `json:"heartbeatFailureCount,omitempty"`
+}
+
+// DisconnectedState is the last known good state of a disconnected cluster.
+type DisconnectedState struct {
+	// LastKnownAPIServerURL is the URL of the API server of the cluster when it was last
+	// connected.
+	// +optional
+	LastKnownAPIServerURL string `json:"lastKnownAPIServerURL,omitempty"`
+
+	// LastSeenAt is the time at which the cluster was found disconnected.
+	// +optional
+	LastSeenAt metav1.Time `json:"lastSeenAt,omitempty"`
+
+	// ReconnectAttempts is the number of failed attempts to reconnect to the cluster.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ReconnectAttempts int32 `json:"reconnectAttempts,omitempty"`
+}
+
+// NodeSummary aggregates the state of the nodes of a cluster.
+type NodeSummary struct {
+	// NodeConditions counts the nodes by status for each node condition type.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	NodeConditions []NodeConditionSummary `json:"nodeConditions,omitempty"`
+}
+
+// NodeConditionSummary counts the nodes of a cluster by the status of a node condition.
+type NodeConditionSummary struct {
+	// Type is the type of the node condition, for example DiskPressure.
+	// +required
+	Type string `json:"type"`
+
+	// TrueCount is the number of nodes with the condition True.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TrueCount int32 `json:"trueCount,omitempty"`
+
+	// FalseCount is the number of nodes with the condition False.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FalseCount int32 `json:"falseCount,omitempty"`
+
+	// UnknownCount is the number of nodes with the condition Unknown or missing.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	UnknownCount int32 `json:"unknownCount,omitempty"`
+}
+
+// ResourceSnapshot is the resources of the cluster at a point in time.
+type ResourceSnapshot struct {
+	// Time is the time at which the snapshot was taken.
+	Time metav1.Time `json:"time"`
+
+	// Resources is the resources of the cluster at that time.
+	Resources Resources `json:"resources"`
 }
 
 // ResourceName is the name identifying various resources in a ResourceList.
@@ -134,6 +758,9 @@ const (
 	ResourceCPU ResourceName = "cpu"
 	// ResourceMemory defines the amount of memory in bytes. (500Gi = 500GiB = 500 * 1024 * 1024 * 1024)
 	ResourceMemory ResourceName = "memory"
+	// ResourcePods defines the number of pods. Clusters report their pod counts in the
+	// PodCapacity and PodAllocatable fields of Resources instead of resource lists.
+	ResourcePods ResourceName = "pods"
 )
 
 // ResourceList defines a map for the quantity of different resources, the definition
@@ -146,6 +773,7 @@ type Property struct {
 	// or customized name to identify the propertie.
 	// +kubebuilder:validation:MaxLength=253
 	// +kubebuilder:validation:MinLength=1
+	// +required
 	Name string `json:"name,omitempty"`
 
 	// Value is a property-dependent string
@@ -154,11 +782,71 @@ type Property struct {
 	Value string `json:"value,omitempty"`
 }
 
+const (
+	// AllowAccessRefUpdateAnnotation allows changing the access refs of a joined
+	// cluster when set to "true".
+	AllowAccessRefUpdateAnnotation = "cluster.x-k8s.io/allow-access-ref-update"
+
+	// AllowTaintEffectWeakeningAnnotation allows weakening the effect of a taint when
+	// set to "true", where validation rejects it.
+	AllowTaintEffectWeakeningAnnotation = "cluster.x-k8s.io/allow-taint-effect-weakening"
+)
+
+const (
+	// ClusterCleanupFinalizer is added to clusters so controllers can clean up the
+	// resources of a cluster before it is removed.
+	ClusterCleanupFinalizer = "cluster.x-k8s.io/cleanup"
+)
+
+const (
+	// ZoneWildcard matches every hub zone in ClusterStatus.ReachableFrom.
+	ZoneWildcard = "*"
+)
+
+const (
+	// PropertyClusterID is the name of the property holding the unique identifier of
+	// the cluster.
+	PropertyClusterID = "id.k8s.io"
+
+	// PropertyClusterSet is the name of the property holding the name of the cluster
+	// set the cluster belongs to.
+	PropertyClusterSet = "clusterset.k8s.io"
+)
+
 const (
 	// ClusterConditionJoined means the cluster has successfully joined the control.
 	ClusterConditionJoined string = "Joined"
 	// Healthey means the cluster is healthy.
 	ClusterConditionHealthy string = "Healthy"
+	// ClusterConditionShuttingDown means the cluster is deleted and its workloads are
+	// being migrated away.
+	ClusterConditionShuttingDown string = "ShuttingDown"
+	// ClusterConditionEtcdHealthy means the etcd cluster backing the cluster is healthy.
+	ClusterConditionEtcdHealthy string = "EtcdHealthy"
+)
+
+// ClusterPhase summarizes the lifecycle of a cluster. Clusters have no phase field: the
+// phase is derived from their Joined and Healthy conditions.
+type ClusterPhase string
+
+const (
+	// ClusterPhasePending means the cluster has not joined.
+	ClusterPhasePending ClusterPhase = "Pending"
+	// ClusterPhaseReady means the cluster has joined and is healthy.
+	ClusterPhaseReady ClusterPhase = "Ready"
+	// ClusterPhaseUnhealthy means the cluster has joined and is not healthy.
+	ClusterPhaseUnhealthy ClusterPhase = "Unhealthy"
+	// ClusterPhaseUnknown means the cluster has joined and its health is unknown.
+	ClusterPhaseUnknown ClusterPhase = "Unknown"
+)
+
+const (
+	// ConditionReasonKubeConfigInvalid means the kubeconfig used to access the cluster
+	// is invalid, for example because its credentials expired.
+	ConditionReasonKubeConfigInvalid string = "KubeConfigInvalid"
+	// ConditionReasonProbingSuspended means the health of the cluster is not updated
+	// because its health probe is suspended.
+	ConditionReasonProbingSuspended string = "ProbingSuspended"
 )
 
 // +genclient
@@ -0,0 +1,46 @@
+package v1alpha1
+
+const (
+	// CNIFeatureNetworkPolicy is the enforcement of Kubernetes NetworkPolicies.
+	CNIFeatureNetworkPolicy = "NetworkPolicy"
+	// CNIFeatureDualStack is IPv4/IPv6 dual-stack networking.
+	CNIFeatureDualStack = "DualStack"
+	// CNIFeatureEncryption is the encryption of pod to pod traffic.
+	CNIFeatureEncryption = "Encryption"
+	// CNIFeatureEBPF is an eBPF based data plane.
+	CNIFeatureEBPF = "eBPF"
+)
+
+// cniFeatures lists the features supported by each known CNI plugin.
+var cniFeatures = map[CNIPlugin]map[string]bool{
+	CNICalico: {
+		CNIFeatureNetworkPolicy: true,
+		CNIFeatureDualStack:     true,
+		CNIFeatureEncryption:    true,
+		CNIFeatureEBPF:          true,
+	},
+	CNICilium: {
+		CNIFeatureNetworkPolicy: true,
+		CNIFeatureDualStack:     true,
+		CNIFeatureEncryption:    true,
+		CNIFeatureEBPF:          true,
+	},
+	CNIFlannel: {
+		CNIFeatureDualStack: true,
+	},
+	CNIWeaveNet: {
+		CNIFeatureNetworkPolicy: true,
+		CNIFeatureEncryption:    true,
+	},
+	CNIAntrea: {
+		CNIFeatureNetworkPolicy: true,
+		CNIFeatureDualStack:     true,
+		CNIFeatureEncryption:    true,
+	},
+}
+
+// SupportsCNIFeature returns true if the CNI plugin of the cluster supports the
+// feature. It returns false for an unknown plugin.
+func SupportsCNIFeature(cluster Cluster, feature string) bool {
+	return cniFeatures[cluster.Status.CNI][feature]
+}
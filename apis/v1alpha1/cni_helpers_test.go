@@ -0,0 +1,31 @@
+package v1alpha1
+
+import "testing"
+
+func TestSupportsCNIFeature(t *testing.T) {
+	cases := []struct {
+		name     string
+		cni      CNIPlugin
+		feature  string
+		expected bool
+	}{
+		{name: "calico network policy", cni: CNICalico, feature: CNIFeatureNetworkPolicy, expected: true},
+		{name: "cilium eBPF", cni: CNICilium, feature: CNIFeatureEBPF, expected: true},
+		{name: "flannel dual stack", cni: CNIFlannel, feature: CNIFeatureDualStack, expected: true},
+		{name: "flannel network policy", cni: CNIFlannel, feature: CNIFeatureNetworkPolicy},
+		{name: "weave net encryption", cni: CNIWeaveNet, feature: CNIFeatureEncryption, expected: true},
+		{name: "antrea eBPF", cni: CNIAntrea, feature: CNIFeatureEBPF},
+		{name: "unknown feature", cni: CNICalico, feature: "Unknown"},
+		{name: "unknown CNI", cni: CNIUnknown, feature: CNIFeatureNetworkPolicy},
+		{name: "unreported CNI", feature: CNIFeatureNetworkPolicy},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := Cluster{Status: ClusterStatus{CNI: c.cni}}
+			if actual := SupportsCNIFeature(cluster, c.feature); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const decodeBufferSize = 4096
+
+// DecodeClusters decodes the clusters in a YAML or JSON stream one document at a time
+// and calls fn for each of them, so large inventories can be imported without holding
+// all clusters in memory. Empty documents are skipped, and documents setting an
+// apiVersion or kind other than those of Cluster are rejected. Decoding stops at the
+// first decode error or error returned by fn, which is returned.
+func DecodeClusters(r io.Reader, fn func(*Cluster) error) error {
+	decoder := yaml.NewYAMLOrJSONDecoder(r, decodeBufferSize)
+	for i := 0; ; {
+		var cluster *Cluster
+		if err := decoder.Decode(&cluster); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("failed to decode document %d: %w", i, err)
+		}
+		if cluster == nil {
+			continue
+		}
+		if cluster.APIVersion != "" && cluster.APIVersion != GroupVersion.String() {
+			return fmt.Errorf("document %d has apiVersion %s, not %s", i, cluster.APIVersion, GroupVersion.String())
+		}
+		if cluster.Kind != "" && cluster.Kind != "Cluster" {
+			return fmt.Errorf("document %d is a %s, not a Cluster", i, cluster.Kind)
+		}
+		if err := fn(cluster); err != nil {
+			return err
+		}
+		i++
+	}
+}
@@ -0,0 +1,80 @@
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeClusters(t *testing.T) {
+	cases := []struct {
+		name          string
+		data          string
+		expectedNames []string
+		expectError   bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:          "yaml documents",
+			data:          "apiVersion: multicluster.x-k8s.io/v1alpha1\nkind: Cluster\nmetadata:\n  name: a\n---\nmetadata:\n  name: b\n",
+			expectedNames: []string{"a", "b"},
+		},
+		{
+			name:          "json stream",
+			data:          `{"kind":"Cluster","metadata":{"name":"a"}} {"metadata":{"name":"b"}}`,
+			expectedNames: []string{"a", "b"},
+		},
+		{
+			name:          "empty documents are skipped",
+			data:          "---\nmetadata:\n  name: a\n---\n---\nmetadata:\n  name: b\n",
+			expectedNames: []string{"a", "b"},
+		},
+		{
+			name:          "malformed document",
+			data:          "metadata:\n  name: a\n---\nspec: [",
+			expectedNames: []string{"a"},
+			expectError:   true,
+		},
+		{
+			name:        "other kind",
+			data:        "apiVersion: multicluster.x-k8s.io/v1alpha1\nkind: ClusterList\n",
+			expectError: true,
+		},
+		{
+			name:        "other api version",
+			data:        "apiVersion: v1\nkind: Cluster\n",
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var names []string
+			err := DecodeClusters(strings.NewReader(c.data), func(cluster *Cluster) error {
+				names = append(names, cluster.Name)
+				return nil
+			})
+			if c.expectError != (err != nil) {
+				t.Errorf("expected error %v, got %v", c.expectError, err)
+			}
+			assertStrings(t, names, c.expectedNames)
+		})
+	}
+}
+
+func TestDecodeClustersCallbackError(t *testing.T) {
+	stop := errors.New("stop")
+	calls := 0
+	err := DecodeClusters(strings.NewReader("metadata:\n  name: a\n---\nmetadata:\n  name: b\n"), func(*Cluster) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("expected %v, got %v", stop, err)
+	}
+	if calls != 1 {
+		t.Errorf("expected decoding to stop after 1 cluster, got %d", calls)
+	}
+}
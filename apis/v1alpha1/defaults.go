@@ -0,0 +1,92 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// DefaultHeartbeatIntervalSeconds is the heartbeat interval used when none is specified.
+	DefaultHeartbeatIntervalSeconds int32 = 60
+
+	// DefaultBackoffMultiplier is the health probe backoff multiplier used when none is
+	// specified.
+	DefaultBackoffMultiplier = "1.5"
+
+	// DefaultHTTPExpectedStatus is the status code expected from the HTTP endpoint of a
+	// health probe when none is specified.
+	DefaultHTTPExpectedStatus int32 = 200
+
+	// DefaultRetryInitialIntervalSeconds is the initial interval of a backoff policy used
+	// when none is specified.
+	DefaultRetryInitialIntervalSeconds int32 = 5
+
+	// DefaultRetryMaxIntervalSeconds is the max interval of a backoff policy used when
+	// none is specified.
+	DefaultRetryMaxIntervalSeconds int32 = 300
+
+	// DefaultRetryMultiplier is the multiplier of a backoff policy used when none is
+	// specified.
+	DefaultRetryMultiplier = "2"
+)
+
+func init() {
+	localSchemeBuilder.Register(RegisterDefaults)
+}
+
+// RegisterDefaults adds defaulters functions to the given scheme.
+// Public to allow building arbitrary schemes.
+func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&Cluster{}, func(obj interface{}) { SetDefaults_Cluster(obj.(*Cluster)) })
+	scheme.AddTypeDefaultingFunc(&ClusterList{}, func(obj interface{}) { SetDefaults_ClusterList(obj.(*ClusterList)) })
+	return nil
+}
+
+// SetDefaults_ClusterList sets defaults for every cluster in the list.
+func SetDefaults_ClusterList(in *ClusterList) {
+	for i := range in.Items {
+		SetDefaults_Cluster(&in.Items[i])
+	}
+}
+
+// SetDefaults_Cluster sets defaults for a cluster.
+func SetDefaults_Cluster(in *Cluster) {
+	SetDefaults_ClusterSpec(&in.Spec)
+}
+
+// SetDefaults_ClusterSpec sets defaults for a cluster spec.
+func SetDefaults_ClusterSpec(in *ClusterSpec) {
+	SetDefaults_HealthProbe(&in.HealthProbe)
+	for i := range in.AccessObjectRefs {
+		if in.AccessObjectRefs[i].BackoffPolicy != nil {
+			SetDefaults_BackoffPolicy(in.AccessObjectRefs[i].BackoffPolicy)
+		}
+	}
+}
+
+// SetDefaults_HealthProbe sets defaults for a health probe. Explicitly set values are
+// never overwritten.
+func SetDefaults_HealthProbe(in *HealthProbe) {
+	if in.HeartbeatIntervalSeconds == 0 {
+		in.HeartbeatIntervalSeconds = DefaultHeartbeatIntervalSeconds
+	}
+	if in.BackoffMultiplier == "" {
+		in.BackoffMultiplier = DefaultBackoffMultiplier
+	}
+	if in.HTTPEndpoint != "" && in.HTTPExpectedStatus == 0 {
+		in.HTTPExpectedStatus = DefaultHTTPExpectedStatus
+	}
+}
+
+// SetDefaults_BackoffPolicy sets defaults for a backoff policy. Explicitly set values
+// are never overwritten.
+func SetDefaults_BackoffPolicy(in *BackoffPolicy) {
+	if in.InitialIntervalSeconds == 0 {
+		in.InitialIntervalSeconds = DefaultRetryInitialIntervalSeconds
+	}
+	if in.MaxIntervalSeconds == 0 {
+		in.MaxIntervalSeconds = DefaultRetryMaxIntervalSeconds
+	}
+	if in.Multiplier == "" {
+		in.Multiplier = DefaultRetryMultiplier
+	}
+}
@@ -0,0 +1,122 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSetDefaults_Cluster(t *testing.T) {
+	cases := []struct {
+		name     string
+		cluster  *Cluster
+		expected *Cluster
+	}{
+		{
+			name:    "zero values are defaulted",
+			cluster: &Cluster{},
+			expected: &Cluster{
+				Spec: ClusterSpec{
+					HealthProbe: HealthProbe{
+						HeartbeatIntervalSeconds: DefaultHeartbeatIntervalSeconds,
+						BackoffMultiplier:        DefaultBackoffMultiplier,
+					},
+				},
+			},
+		},
+		{
+			name: "explicit values are kept",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					HealthProbe: HealthProbe{
+						HeartbeatIntervalSeconds: 30,
+						BackoffMultiplier:        "3",
+						HTTPEndpoint:             "https://example.com/healthz",
+						HTTPExpectedStatus:       204,
+					},
+				},
+			},
+			expected: &Cluster{
+				Spec: ClusterSpec{
+					HealthProbe: HealthProbe{
+						HeartbeatIntervalSeconds: 30,
+						BackoffMultiplier:        "3",
+						HTTPEndpoint:             "https://example.com/healthz",
+						HTTPExpectedStatus:       204,
+					},
+				},
+			},
+		},
+		{
+			name: "expected status is defaulted for http probes",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					HealthProbe: HealthProbe{HTTPEndpoint: "https://example.com/healthz"},
+				},
+			},
+			expected: &Cluster{
+				Spec: ClusterSpec{
+					HealthProbe: HealthProbe{
+						HeartbeatIntervalSeconds: DefaultHeartbeatIntervalSeconds,
+						BackoffMultiplier:        DefaultBackoffMultiplier,
+						HTTPEndpoint:             "https://example.com/healthz",
+						HTTPExpectedStatus:       DefaultHTTPExpectedStatus,
+					},
+				},
+			},
+		},
+		{
+			name: "backoff policies of access refs are defaulted",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					AccessObjectRefs: []AccessObjectRef{
+						{Name: "without-policy"},
+						{Name: "with-policy", BackoffPolicy: &BackoffPolicy{MaxRetries: 3}},
+					},
+				},
+			},
+			expected: &Cluster{
+				Spec: ClusterSpec{
+					AccessObjectRefs: []AccessObjectRef{
+						{Name: "without-policy"},
+						{Name: "with-policy", BackoffPolicy: &BackoffPolicy{
+							InitialIntervalSeconds: DefaultRetryInitialIntervalSeconds,
+							MaxIntervalSeconds:     DefaultRetryMaxIntervalSeconds,
+							Multiplier:             DefaultRetryMultiplier,
+							MaxRetries:             3,
+						}},
+					},
+					HealthProbe: HealthProbe{
+						HeartbeatIntervalSeconds: DefaultHeartbeatIntervalSeconds,
+						BackoffMultiplier:        DefaultBackoffMultiplier,
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			SetDefaults_Cluster(c.cluster)
+			if !equality.Semantic.DeepEqual(c.cluster, c.expected) {
+				t.Errorf("expected %#v, got %#v", c.expected, c.cluster)
+			}
+		})
+	}
+}
+
+func TestRegisterDefaults(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	list := &ClusterList{Items: []Cluster{{}, {}}}
+	scheme.Default(list)
+	for i, cluster := range list.Items {
+		if cluster.Spec.HealthProbe.HeartbeatIntervalSeconds != DefaultHeartbeatIntervalSeconds {
+			t.Errorf("expected cluster %d to be defaulted, got %#v", i, cluster.Spec.HealthProbe)
+		}
+	}
+}
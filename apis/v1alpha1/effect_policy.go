@@ -0,0 +1,90 @@
+package v1alpha1
+
+// EffectPolicy weights the soft taint effects for scheduling. Untolerated taints with a
+// soft effect add the weight of their effect to the score of a cluster, so a higher
+// score means a less preferred cluster.
+// +kubebuilder:object:generate=false
+type EffectPolicy map[TaintEffect]int
+
+// DefaultEffectPolicy weights each untolerated PreferNoSelect taint with 1.
+var DefaultEffectPolicy = EffectPolicy{
+	TaintEffectPreferNoSelect: 1,
+}
+
+// Score returns the sum of the weights of the taints not tolerated by any of the
+// tolerations, and whether an untolerated taint has a hard effect, which blocks the
+// cluster from being selected. Effects missing from the policy weigh 0.
+func (p EffectPolicy) Score(taints []Taint, tolerations []Toleration) (score int, blocked bool) {
+	for _, taint := range taints {
+		if isTaintTolerated(taint, tolerations) {
+			continue
+		}
+		if taint.Effect.IsHard() {
+			blocked = true
+			continue
+		}
+		score += p[taint.Effect]
+	}
+	return score, blocked
+}
+
+func isTaintTolerated(taint Taint, tolerations []Toleration) bool {
+	for _, t := range tolerations {
+		if t.ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// PlacementFeasible returns whether a placement with the tolerations may select the
+// cluster, along with the hard taints of the cluster that none of the tolerations
+// tolerate. Soft taints never make a placement infeasible.
+func PlacementFeasible(c *Cluster, tolerations []Toleration) (feasible bool, blocking []Taint) {
+	for _, taint := range c.Spec.Taints {
+		if taint.Effect.IsHard() && !isTaintTolerated(taint, tolerations) {
+			blocking = append(blocking, taint)
+		}
+	}
+	return len(blocking) == 0, blocking
+}
+
+// RequiredTolerationsOption configures RequiredTolerations.
+// +kubebuilder:object:generate=false
+type RequiredTolerationsOption func(*requiredTolerationsOptions)
+
+type requiredTolerationsOptions struct {
+	includeSoftTaints bool
+}
+
+// IncludeSoftTaints makes RequiredTolerations also return tolerations for the soft
+// taints of the cluster.
+func IncludeSoftTaints() RequiredTolerationsOption {
+	return func(o *requiredTolerationsOptions) {
+		o.includeSoftTaints = true
+	}
+}
+
+// RequiredTolerations returns the tolerations a placement needs to select the cluster:
+// a toleration with the Exists operator for the key and effect of each hard taint of
+// the cluster. Soft taints are skipped unless IncludeSoftTaints is given. The result
+// is deduplicated and sorted like MergeTolerations.
+func RequiredTolerations(c *Cluster, opts ...RequiredTolerationsOption) []Toleration {
+	o := &requiredTolerationsOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var tolerations []Toleration
+	for _, taint := range c.Spec.Taints {
+		if !taint.Effect.IsHard() && !o.includeSoftTaints {
+			continue
+		}
+		tolerations = append(tolerations, Toleration{
+			Key:      taint.Key,
+			Operator: TolerationOpExists,
+			Effect:   taint.Effect,
+		})
+	}
+	return MergeTolerations(tolerations)
+}
@@ -0,0 +1,175 @@
+package v1alpha1
+
+import "testing"
+
+func TestEffectPolicyScore(t *testing.T) {
+	soft := Taint{Key: "soft", Effect: TaintEffectPreferNoSelect}
+	otherSoft := Taint{Key: "other-soft", Effect: TaintEffectPreferNoSelect}
+	hard := Taint{Key: "hard", Effect: TaintEffectNoSelect}
+	ifNew := Taint{Key: "if-new", Effect: TaintEffectNoSelectIfNew}
+
+	cases := []struct {
+		name            string
+		policy          EffectPolicy
+		taints          []Taint
+		tolerations     []Toleration
+		expectedScore   int
+		expectedBlocked bool
+	}{
+		{
+			name:   "no taints",
+			policy: DefaultEffectPolicy,
+		},
+		{
+			name:          "untolerated soft taints",
+			policy:        DefaultEffectPolicy,
+			taints:        []Taint{soft, otherSoft},
+			expectedScore: 2,
+		},
+		{
+			name:          "tolerated soft taint",
+			policy:        DefaultEffectPolicy,
+			taints:        []Taint{soft, otherSoft},
+			tolerations:   []Toleration{{Key: "soft", Operator: TolerationOpExists}},
+			expectedScore: 1,
+		},
+		{
+			name:          "custom weight",
+			policy:        EffectPolicy{TaintEffectPreferNoSelect: 5},
+			taints:        []Taint{soft, otherSoft},
+			expectedScore: 10,
+		},
+		{
+			name:   "effect missing from the policy",
+			policy: EffectPolicy{},
+			taints: []Taint{soft},
+		},
+		{
+			name:            "untolerated hard taints block",
+			policy:          DefaultEffectPolicy,
+			taints:          []Taint{soft, hard},
+			expectedScore:   1,
+			expectedBlocked: true,
+		},
+		{
+			name:            "no select if new is hard",
+			policy:          DefaultEffectPolicy,
+			taints:          []Taint{ifNew},
+			expectedBlocked: true,
+		},
+		{
+			name:        "tolerated hard taint",
+			policy:      DefaultEffectPolicy,
+			taints:      []Taint{hard},
+			tolerations: []Toleration{{Key: "hard", Operator: TolerationOpExists, Effect: TaintEffectNoSelect}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			score, blocked := c.policy.Score(c.taints, c.tolerations)
+			if score != c.expectedScore || blocked != c.expectedBlocked {
+				t.Errorf("expected (%d, %v), got (%d, %v)", c.expectedScore, c.expectedBlocked, score, blocked)
+			}
+		})
+	}
+}
+
+func TestPlacementFeasible(t *testing.T) {
+	hard := Taint{Key: "hard", Effect: TaintEffectNoSelect}
+	ifNew := Taint{Key: "if-new", Effect: TaintEffectNoSelectIfNew}
+	soft := Taint{Key: "soft", Effect: TaintEffectPreferNoSelect}
+
+	cases := []struct {
+		name             string
+		taints           []Taint
+		tolerations      []Toleration
+		expectedFeasible bool
+		expectedBlocking []string
+	}{
+		{
+			name:             "no taints",
+			expectedFeasible: true,
+		},
+		{
+			name:             "soft taints never block",
+			taints:           []Taint{soft},
+			expectedFeasible: true,
+		},
+		{
+			name:             "untolerated hard taints block",
+			taints:           []Taint{hard, soft, ifNew},
+			expectedBlocking: []string{"hard", "if-new"},
+		},
+		{
+			name:             "tolerated hard taint",
+			taints:           []Taint{hard, ifNew},
+			tolerations:      []Toleration{{Key: "hard", Operator: TolerationOpExists}},
+			expectedBlocking: []string{"if-new"},
+		},
+		{
+			name:             "all hard taints tolerated",
+			taints:           []Taint{hard, ifNew},
+			tolerations:      []Toleration{{Operator: TolerationOpExists}},
+			expectedFeasible: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.Spec.Taints = c.taints
+
+			feasible, blocking := PlacementFeasible(&cluster, c.tolerations)
+			if feasible != c.expectedFeasible {
+				t.Errorf("expected feasible %v, got %v", c.expectedFeasible, feasible)
+			}
+			assertStrings(t, taintKeys(blocking), c.expectedBlocking)
+		})
+	}
+}
+
+func TestRequiredTolerations(t *testing.T) {
+	cluster := newCluster("cluster1")
+	cluster.Spec.Taints = []Taint{
+		{Key: "zone", Value: "us-east", Effect: TaintEffectNoSelect},
+		{Key: "maintenance", Effect: TaintEffectNoSelectIfNew},
+		{Key: "spot", Value: "true", Effect: TaintEffectPreferNoSelect},
+		{Key: "maintenance", Effect: TaintEffectNoSelect},
+	}
+
+	cases := []struct {
+		name     string
+		opts     []RequiredTolerationsOption
+		expected []string
+	}{
+		{
+			name:     "hard taints",
+			expected: []string{"maintenance:NoSelect", "maintenance:NoSelectIfNew", "zone:NoSelect"},
+		},
+		{
+			name:     "include soft taints",
+			opts:     []RequiredTolerationsOption{IncludeSoftTaints()},
+			expected: []string{"maintenance:NoSelect", "maintenance:NoSelectIfNew", "spot:PreferNoSelect", "zone:NoSelect"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tolerations := RequiredTolerations(&cluster, c.opts...)
+			var actual []string
+			for _, toleration := range tolerations {
+				actual = append(actual, toleration.String())
+			}
+			assertStrings(t, actual, c.expected)
+
+			if feasible, blocking := PlacementFeasible(&cluster, tolerations); !feasible {
+				t.Errorf("expected the required tolerations to make the placement feasible, blocked by %v", blocking)
+			}
+		})
+	}
+
+	if tolerations := RequiredTolerations(&Cluster{}); len(tolerations) != 0 {
+		t.Errorf("expected no tolerations for an untainted cluster, got %v", tolerations)
+	}
+}
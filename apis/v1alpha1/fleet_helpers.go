@@ -0,0 +1,249 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FleetAvailabilityOption configures how FleetAvailability counts clusters.
+// +kubebuilder:object:generate=false
+type FleetAvailabilityOption func(*fleetAvailabilityOptions)
+
+type fleetAvailabilityOptions struct {
+	inMaintenance func(cluster *Cluster, now time.Time) bool
+}
+
+// ExcludeMaintenance excludes clusters for which inMaintenance returns true from both
+// the available count and the total.
+func ExcludeMaintenance(inMaintenance func(cluster *Cluster, now time.Time) bool) FleetAvailabilityOption {
+	return func(o *fleetAvailabilityOptions) {
+		o.inMaintenance = inMaintenance
+	}
+}
+
+// FleetAvailability returns the number of clusters healthy at now, as returned by
+// Cluster.IsHealthyAt, the number of clusters counted and the ratio between them. The
+// ratio is 0 for an empty fleet.
+func FleetAvailability(clusters []Cluster, now time.Time, opts ...FleetAvailabilityOption) (available, total int, ratio float64) {
+	o := &fleetAvailabilityOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	for i := range clusters {
+		cluster := &clusters[i]
+		if o.inMaintenance != nil && o.inMaintenance(cluster, now) {
+			continue
+		}
+		total++
+		if cluster.IsHealthyAt(now) {
+			available++
+		}
+	}
+
+	if total == 0 {
+		return 0, 0, 0
+	}
+	return available, total, float64(available) / float64(total)
+}
+
+// IndexByName returns the clusters in the list keyed by name. Clusters in different
+// namespaces with the same name collide and the last one wins.
+func (l *ClusterList) IndexByName() map[string]*Cluster {
+	index := make(map[string]*Cluster, len(l.Items))
+	for i := range l.Items {
+		index[l.Items[i].Name] = &l.Items[i]
+	}
+	return index
+}
+
+// IndexByClusterID returns the clusters in the list keyed by the value of their
+// cluster id property. Clusters without the property are skipped. When several
+// clusters report the same id, the first one is indexed and an error listing the
+// duplicate ids is returned along with the index.
+func (l *ClusterList) IndexByClusterID() (map[string]*Cluster, error) {
+	index := make(map[string]*Cluster, len(l.Items))
+	var duplicates []string
+	for i := range l.Items {
+		id, ok := l.Items[i].GetProperty(PropertyClusterID)
+		if !ok {
+			continue
+		}
+		if existing, ok := index[id]; ok {
+			duplicates = append(duplicates, fmt.Sprintf("%s (clusters %s and %s)", id, existing.Name, l.Items[i].Name))
+			continue
+		}
+		index[id] = &l.Items[i]
+	}
+
+	if len(duplicates) > 0 {
+		return index, fmt.Errorf("duplicate cluster ids: %s", strings.Join(duplicates, ", "))
+	}
+	return index, nil
+}
+
+// GroupByTaintEffect groups the clusters by the effects of their taints. A cluster
+// with taints of several effects appears in each of the corresponding groups, and
+// clusters without taints are not included.
+func GroupByTaintEffect(clusters []Cluster) map[TaintEffect][]Cluster {
+	groups := map[TaintEffect][]Cluster{}
+	for _, cluster := range clusters {
+		effects := map[TaintEffect]struct{}{}
+		for _, t := range cluster.Spec.Taints {
+			if _, ok := effects[t.Effect]; ok {
+				continue
+			}
+			effects[t.Effect] = struct{}{}
+			groups[t.Effect] = append(groups[t.Effect], cluster)
+		}
+	}
+	return groups
+}
+
+// ClustersWithEffect returns the clusters having at least one taint with the effect.
+func ClustersWithEffect(clusters []Cluster, effect TaintEffect) []Cluster {
+	var result []Cluster
+	for _, cluster := range clusters {
+		if AnyTaintEffect(cluster, effect) {
+			result = append(result, cluster)
+		}
+	}
+	return result
+}
+
+// ClustersWithinBudget returns the clusters whose capacity is less than or equal to max
+// for every resource in max. Resources not in max are ignored, and a cluster that does
+// not report the capacity of a resource in max is excluded since it cannot be checked.
+func ClustersWithinBudget(clusters []Cluster, max ResourceList) []Cluster {
+	var result []Cluster
+	for _, cluster := range clusters {
+		if withinBudget(cluster.Status.Resources.Capacity, max) {
+			result = append(result, cluster)
+		}
+	}
+	return result
+}
+
+func withinBudget(capacity, max ResourceList) bool {
+	for name, limit := range max {
+		q, ok := capacity[name]
+		if !ok || q.Cmp(limit) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// AggregateResources returns the sum of the resources of the clusters. Resources
+// reported by only some of the clusters are summed over those clusters.
+func AggregateResources(clusters []Cluster) Resources {
+	var total Resources
+	for i := range clusters {
+		r := clusters[i].Status.Resources
+		total.Capacity = addResourceList(total.Capacity, r.Capacity)
+		total.Allocatable = addResourceList(total.Allocatable, r.Allocatable)
+		total.Reserved = addResourceList(total.Reserved, r.Reserved)
+		total.GPUCapacity = addQuantities(total.GPUCapacity, r.GPUCapacity)
+		total.GPUAllocatable = addQuantities(total.GPUAllocatable, r.GPUAllocatable)
+		total.PodCapacity += r.PodCapacity
+		total.PodAllocatable += r.PodAllocatable
+	}
+	return total
+}
+
+// AggregateByLabel groups the clusters by the value of the label and returns the sum of
+// the resources of each group. Clusters without the label are grouped under the empty
+// value.
+func AggregateByLabel(clusters []Cluster, labelKey string) map[string]Resources {
+	groups := map[string][]Cluster{}
+	for i := range clusters {
+		value := clusters[i].Labels[labelKey]
+		groups[value] = append(groups[value], clusters[i])
+	}
+
+	aggregated := make(map[string]Resources, len(groups))
+	for value, group := range groups {
+		aggregated[value] = AggregateResources(group)
+	}
+	return aggregated
+}
+
+func addResourceList(total, r ResourceList) ResourceList {
+	if len(r) == 0 {
+		return total
+	}
+	if total == nil {
+		total = ResourceList{}
+	}
+	for name, q := range r {
+		sum := total[name]
+		sum.Add(q)
+		total[name] = sum
+	}
+	return total
+}
+
+func addQuantities(total, r map[string]resource.Quantity) map[string]resource.Quantity {
+	if len(r) == 0 {
+		return total
+	}
+	if total == nil {
+		total = map[string]resource.Quantity{}
+	}
+	for name, q := range r {
+		sum := total[name]
+		sum.Add(q)
+		total[name] = sum
+	}
+	return total
+}
+
+// AllResourceNames returns the sorted names of the resources in the capacity or the
+// allocatable resources of any of the clusters.
+func AllResourceNames(clusters []Cluster) []ResourceName {
+	names := ResourceList{}
+	for i := range clusters {
+		for name := range clusters[i].Status.Resources.Capacity {
+			names[name] = resource.Quantity{}
+		}
+		for name := range clusters[i].Status.Resources.Allocatable {
+			names[name] = resource.Quantity{}
+		}
+	}
+	return names.names()
+}
+
+// SortByJoinTime sorts the clusters in place by the last transition time of their
+// Joined condition, oldest first. Clusters that have not joined are sorted last by
+// creation time. Ties are broken by name.
+func SortByJoinTime(clusters []Cluster) {
+	joinTime := func(c *Cluster) (time.Time, bool) {
+		joined := meta.FindStatusCondition(c.Status.Conditions, ClusterConditionJoined)
+		if joined == nil || joined.Status != metav1.ConditionTrue {
+			return time.Time{}, false
+		}
+		return joined.LastTransitionTime.Time, true
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		a, b := &clusters[i], &clusters[j]
+		aTime, aJoined := joinTime(a)
+		bTime, bJoined := joinTime(b)
+		if aJoined != bJoined {
+			return aJoined
+		}
+		if !aJoined {
+			aTime, bTime = a.CreationTimestamp.Time, b.CreationTimestamp.Time
+		}
+		if !aTime.Equal(bTime) {
+			return aTime.Before(bTime)
+		}
+		return a.Name < b.Name
+	})
+}
@@ -0,0 +1,503 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newCluster(name string, conditions ...metav1.Condition) Cluster {
+	return Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     ClusterStatus{Conditions: conditions},
+	}
+}
+
+func newCondition(condType string, status metav1.ConditionStatus) metav1.Condition {
+	return metav1.Condition{Type: condType, Status: status, Reason: "Test"}
+}
+
+func TestFleetAvailability(t *testing.T) {
+	now := time.Now()
+	healthy := newCondition(ClusterConditionHealthy, metav1.ConditionTrue)
+	inMaintenance := func(cluster *Cluster, _ time.Time) bool {
+		return cluster.Labels["maintenance"] == "true"
+	}
+	onTime := func(name string) Cluster {
+		return newHeartbeatCluster(name, now.Add(-30*time.Second), healthy)
+	}
+	stale := newHeartbeatCluster("stale", now.Add(-5*time.Minute), healthy)
+	suspended := newCluster("suspended", healthy)
+	suspended.Spec.HealthProbe.Suspend = true
+	maintained := newHeartbeatCluster("maintained", now.Add(-5*time.Minute))
+	maintained.Labels = map[string]string{"maintenance": "true"}
+
+	cases := []struct {
+		name              string
+		clusters          []Cluster
+		opts              []FleetAvailabilityOption
+		expectedAvailable int
+		expectedTotal     int
+		expectedRatio     float64
+	}{
+		{
+			name: "empty fleet",
+		},
+		{
+			name:              "all healthy",
+			clusters:          []Cluster{onTime("a"), onTime("b")},
+			expectedAvailable: 2,
+			expectedTotal:     2,
+			expectedRatio:     1,
+		},
+		{
+			name:              "stale heartbeat lowers the ratio despite a Healthy condition",
+			clusters:          []Cluster{onTime("a"), stale},
+			expectedAvailable: 1,
+			expectedTotal:     2,
+			expectedRatio:     0.5,
+		},
+		{
+			name:              "clusters without heartbeat are unavailable",
+			clusters:          []Cluster{onTime("a"), newCluster("b", healthy), newCluster("c"), onTime("d")},
+			expectedAvailable: 2,
+			expectedTotal:     4,
+			expectedRatio:     0.5,
+		},
+		{
+			name:              "suspended clusters keep their Healthy condition",
+			clusters:          []Cluster{suspended, stale},
+			expectedAvailable: 1,
+			expectedTotal:     2,
+			expectedRatio:     0.5,
+		},
+		{
+			name:              "maintenance counted without option",
+			clusters:          []Cluster{onTime("a"), maintained},
+			expectedAvailable: 1,
+			expectedTotal:     2,
+			expectedRatio:     0.5,
+		},
+		{
+			name:              "maintenance excluded",
+			clusters:          []Cluster{onTime("a"), maintained},
+			opts:              []FleetAvailabilityOption{ExcludeMaintenance(inMaintenance)},
+			expectedAvailable: 1,
+			expectedTotal:     1,
+			expectedRatio:     1,
+		},
+		{
+			name:     "whole fleet in maintenance",
+			clusters: []Cluster{maintained},
+			opts:     []FleetAvailabilityOption{ExcludeMaintenance(inMaintenance)},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			available, total, ratio := FleetAvailability(c.clusters, now, c.opts...)
+			if available != c.expectedAvailable || total != c.expectedTotal || ratio != c.expectedRatio {
+				t.Errorf("expected (%d, %d, %v), got (%d, %d, %v)",
+					c.expectedAvailable, c.expectedTotal, c.expectedRatio, available, total, ratio)
+			}
+		})
+	}
+}
+
+// newHeartbeatCluster returns a cluster with a heartbeat interval of 60 seconds whose
+// agent last reported a successful heartbeat at lastHeartbeat.
+func newHeartbeatCluster(name string, lastHeartbeat time.Time, conditions ...metav1.Condition) Cluster {
+	cluster := newCluster(name, conditions...)
+	cluster.Spec.HealthProbe.HeartbeatIntervalSeconds = 60
+	cluster.Status.AgentStatus = &AgentStatus{LastSuccessfulHeartbeatTime: &metav1.Time{Time: lastHeartbeat}}
+	return cluster
+}
+
+func newClusterWithID(name, id string) Cluster {
+	cluster := newCluster(name)
+	cluster.Status.Properties = []Property{{Name: PropertyClusterID, Value: id}}
+	return cluster
+}
+
+func TestIndexByName(t *testing.T) {
+	l := &ClusterList{Items: []Cluster{newCluster("a"), newCluster("b")}}
+
+	index := l.IndexByName()
+	if len(index) != 2 {
+		t.Fatalf("expected 2 clusters, got %v", index)
+	}
+	for i := range l.Items {
+		if index[l.Items[i].Name] != &l.Items[i] {
+			t.Errorf("expected %s to point into the list", l.Items[i].Name)
+		}
+	}
+}
+
+func TestIndexByClusterID(t *testing.T) {
+	cases := []struct {
+		name        string
+		clusters    []Cluster
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name:     "empty list",
+			expected: map[string]string{},
+		},
+		{
+			name:     "unique ids",
+			clusters: []Cluster{newClusterWithID("a", "id-a"), newClusterWithID("b", "id-b")},
+			expected: map[string]string{"id-a": "a", "id-b": "b"},
+		},
+		{
+			name:     "clusters without id are skipped",
+			clusters: []Cluster{newClusterWithID("a", "id-a"), newCluster("b")},
+			expected: map[string]string{"id-a": "a"},
+		},
+		{
+			name:        "duplicate ids keep the first cluster",
+			clusters:    []Cluster{newClusterWithID("a", "id"), newClusterWithID("b", "id"), newClusterWithID("c", "id-c")},
+			expected:    map[string]string{"id": "a", "id-c": "c"},
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			l := &ClusterList{Items: c.clusters}
+			index, err := l.IndexByClusterID()
+			if c.expectError != (err != nil) {
+				t.Errorf("expected error %v, got %v", c.expectError, err)
+			}
+			if len(index) != len(c.expected) {
+				t.Fatalf("expected %v, got %v", c.expected, index)
+			}
+			for id, name := range c.expected {
+				if cluster, ok := index[id]; !ok || cluster.Name != name {
+					t.Errorf("expected id %q to index cluster %q, got %v", id, name, cluster)
+				}
+			}
+		})
+	}
+}
+
+func newTaintedCluster(name string, effects ...TaintEffect) Cluster {
+	cluster := newCluster(name)
+	for i, effect := range effects {
+		cluster.Spec.Taints = append(cluster.Spec.Taints, Taint{Key: fmt.Sprintf("key%d", i), Effect: effect})
+	}
+	return cluster
+}
+
+// clusterNames returns the names of the clusters in order.
+func clusterNames(clusters []Cluster) []string {
+	var names []string
+	for _, cluster := range clusters {
+		names = append(names, cluster.Name)
+	}
+	return names
+}
+
+func TestGroupByTaintEffect(t *testing.T) {
+	cases := []struct {
+		name     string
+		clusters []Cluster
+		expected map[TaintEffect][]string
+	}{
+		{
+			name:     "no clusters",
+			expected: map[TaintEffect][]string{},
+		},
+		{
+			name:     "clusters without taints",
+			clusters: []Cluster{newCluster("a")},
+			expected: map[TaintEffect][]string{},
+		},
+		{
+			name: "clusters with multiple effects are in multiple groups",
+			clusters: []Cluster{
+				newTaintedCluster("a", TaintEffectNoSelect),
+				newTaintedCluster("b", TaintEffectNoSelect, TaintEffectPreferNoSelect, TaintEffectNoSelect),
+				newTaintedCluster("c", TaintEffectPreferNoSelect),
+				newCluster("d"),
+			},
+			expected: map[TaintEffect][]string{
+				TaintEffectNoSelect:       {"a", "b"},
+				TaintEffectPreferNoSelect: {"b", "c"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			groups := GroupByTaintEffect(c.clusters)
+			if groups == nil || len(groups) != len(c.expected) {
+				t.Fatalf("expected %v, got %v", c.expected, groups)
+			}
+			for effect, names := range c.expected {
+				if actual := clusterNames(groups[effect]); strings.Join(actual, ",") != strings.Join(names, ",") {
+					t.Errorf("expected %s clusters %v, got %v", effect, names, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestClustersWithEffect(t *testing.T) {
+	clusters := []Cluster{
+		newTaintedCluster("a", TaintEffectNoSelect),
+		newTaintedCluster("b", TaintEffectPreferNoSelect, TaintEffectNoSelect),
+		newTaintedCluster("c", TaintEffectPreferNoSelect),
+	}
+
+	if actual := clusterNames(ClustersWithEffect(clusters, TaintEffectNoSelect)); strings.Join(actual, ",") != "a,b" {
+		t.Errorf("expected [a b], got %v", actual)
+	}
+	if actual := ClustersWithEffect(clusters, TaintEffectNoSelectIfNew); len(actual) != 0 {
+		t.Errorf("expected no clusters, got %v", clusterNames(actual))
+	}
+}
+
+func TestClustersWithinBudget(t *testing.T) {
+	withCapacity := func(name, cpu, memory string) Cluster {
+		cluster := newCluster(name)
+		cluster.Status.Resources.Capacity = ResourceList{}
+		if cpu != "" {
+			cluster.Status.Resources.Capacity[ResourceCPU] = resource.MustParse(cpu)
+		}
+		if memory != "" {
+			cluster.Status.Resources.Capacity[ResourceMemory] = resource.MustParse(memory)
+		}
+		return cluster
+	}
+	clusters := []Cluster{
+		withCapacity("small", "4", "16Gi"),
+		withCapacity("medium", "16", "64Gi"),
+		withCapacity("large", "64", "256Gi"),
+		withCapacity("unreported", "", ""),
+	}
+
+	cases := []struct {
+		name     string
+		max      ResourceList
+		expected []string
+	}{
+		{
+			name:     "no budget",
+			expected: []string{"small", "medium", "large", "unreported"},
+		},
+		{
+			name:     "cpu budget",
+			max:      ResourceList{ResourceCPU: resource.MustParse("16")},
+			expected: []string{"small", "medium"},
+		},
+		{
+			name:     "cpu and memory budget",
+			max:      ResourceList{ResourceCPU: resource.MustParse("64"), ResourceMemory: resource.MustParse("32Gi")},
+			expected: []string{"small"},
+		},
+		{
+			name: "budget below every cluster",
+			max:  ResourceList{ResourceMemory: resource.MustParse("1Gi")},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual := clusterNames(ClustersWithinBudget(clusters, c.max))
+			if strings.Join(actual, ",") != strings.Join(c.expected, ",") {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAggregateResources(t *testing.T) {
+	withResources := func(name, env string, r Resources) Cluster {
+		cluster := newCluster(name)
+		if env != "" {
+			cluster.Labels = map[string]string{"env": env}
+		}
+		cluster.Status.Resources = r
+		return cluster
+	}
+	clusters := []Cluster{
+		withResources("a", "prod", Resources{
+			Capacity:       ResourceList{ResourceCPU: resource.MustParse("4"), ResourceMemory: resource.MustParse("16Gi")},
+			Allocatable:    ResourceList{ResourceCPU: resource.MustParse("3500m")},
+			GPUCapacity:    map[string]resource.Quantity{"A100": resource.MustParse("8")},
+			PodCapacity:    110,
+			PodAllocatable: 100,
+		}),
+		withResources("b", "prod", Resources{
+			Capacity:       ResourceList{ResourceCPU: resource.MustParse("8")},
+			Reserved:       ResourceList{ResourceCPU: resource.MustParse("500m")},
+			GPUCapacity:    map[string]resource.Quantity{"A100": resource.MustParse("4"), "T4": resource.MustParse("2")},
+			PodCapacity:    250,
+			PodAllocatable: 200,
+		}),
+		withResources("c", "dev", Resources{
+			Capacity:    ResourceList{ResourceCPU: resource.MustParse("2")},
+			PodCapacity: 50,
+		}),
+		withResources("d", "", Resources{}),
+	}
+
+	total := AggregateResources(clusters)
+	assertResourceList(t, total.Capacity, ResourceList{ResourceCPU: resource.MustParse("14"), ResourceMemory: resource.MustParse("16Gi")})
+	assertResourceList(t, total.Allocatable, ResourceList{ResourceCPU: resource.MustParse("3500m")})
+	assertResourceList(t, total.Reserved, ResourceList{ResourceCPU: resource.MustParse("500m")})
+	assertResourceList(t, ResourceList{"A100": total.GPUCapacity["A100"], "T4": total.GPUCapacity["T4"]},
+		ResourceList{"A100": resource.MustParse("12"), "T4": resource.MustParse("2")})
+	if total.GPUAllocatable != nil {
+		t.Errorf("expected no GPU allocatable, got %v", total.GPUAllocatable)
+	}
+	if total.PodCapacity != 410 || total.PodAllocatable != 300 {
+		t.Errorf("expected 410 pod capacity and 300 pods allocatable, got %d and %d", total.PodCapacity, total.PodAllocatable)
+	}
+	if capacity := clusters[0].Status.Resources.Capacity[ResourceCPU]; capacity.Cmp(resource.MustParse("4")) != 0 {
+		t.Errorf("expected the resources of the clusters to be unchanged, got cpu %s", capacity.String())
+	}
+
+	if empty := AggregateResources(nil); empty.Capacity != nil || empty.GPUCapacity != nil {
+		t.Errorf("expected empty resources, got %v", empty)
+	}
+
+	groups := AggregateByLabel(clusters, "env")
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 groups, got %v", groups)
+	}
+	assertResourceList(t, groups["prod"].Capacity, ResourceList{ResourceCPU: resource.MustParse("12"), ResourceMemory: resource.MustParse("16Gi")})
+	assertResourceList(t, groups["dev"].Capacity, ResourceList{ResourceCPU: resource.MustParse("2")})
+	if prod := groups["prod"]; prod.PodCapacity != 360 || prod.PodAllocatable != 300 {
+		t.Errorf("expected 360 pod capacity and 300 pods allocatable in prod, got %d and %d", prod.PodCapacity, prod.PodAllocatable)
+	}
+	if unlabeled := groups[""]; unlabeled.Capacity != nil {
+		t.Errorf("expected the unlabeled group to have no capacity, got %v", unlabeled.Capacity)
+	}
+}
+
+func TestAllResourceNames(t *testing.T) {
+	withResources := func(capacity, allocatable ResourceList) Cluster {
+		cluster := newCluster("cluster1")
+		cluster.Status.Resources = Resources{Capacity: capacity, Allocatable: allocatable}
+		return cluster
+	}
+	names := func(names []ResourceName) []string {
+		var result []string
+		for _, name := range names {
+			result = append(result, string(name))
+		}
+		return result
+	}
+
+	cases := []struct {
+		name     string
+		clusters []Cluster
+		expected []string
+	}{
+		{
+			name: "no clusters",
+		},
+		{
+			name:     "no resources",
+			clusters: []Cluster{withResources(nil, nil)},
+		},
+		{
+			name: "union of capacity and allocatable",
+			clusters: []Cluster{
+				withResources(
+					ResourceList{ResourceMemory: resource.MustParse("16Gi"), ResourceCPU: resource.MustParse("4")},
+					ResourceList{ResourceCPU: resource.MustParse("3")},
+				),
+				withResources(
+					ResourceList{ResourceCPU: resource.MustParse("8"), "nvidia.com/gpu": resource.MustParse("2")},
+					nil,
+				),
+				withResources(
+					nil,
+					ResourceList{"ephemeral-storage": resource.MustParse("100Gi"), "example.com/fpga": resource.MustParse("1")},
+				),
+			},
+			expected: []string{"cpu", "ephemeral-storage", "example.com/fpga", "memory", "nvidia.com/gpu"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assertStrings(t, names(AllResourceNames(c.clusters)), c.expected)
+		})
+	}
+}
+
+func TestSortByJoinTime(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	joined := func(name string, joinedAt, createdAt time.Duration) Cluster {
+		condition := newCondition(ClusterConditionJoined, metav1.ConditionTrue)
+		condition.LastTransitionTime = metav1.NewTime(start.Add(joinedAt))
+		cluster := newCluster(name, condition)
+		cluster.CreationTimestamp = metav1.NewTime(start.Add(createdAt))
+		return cluster
+	}
+	pending := func(name string, status metav1.ConditionStatus, createdAt time.Duration) Cluster {
+		var cluster Cluster
+		if status == "" {
+			cluster = newCluster(name)
+		} else {
+			cluster = newCluster(name, newCondition(ClusterConditionJoined, status))
+		}
+		cluster.CreationTimestamp = metav1.NewTime(start.Add(createdAt))
+		return cluster
+	}
+
+	cases := []struct {
+		name     string
+		clusters []Cluster
+		expected []string
+	}{
+		{
+			name: "no clusters",
+		},
+		{
+			name: "joined clusters by join time",
+			clusters: []Cluster{
+				joined("c", 3*time.Hour, 0),
+				joined("a", 2*time.Hour, time.Hour),
+				joined("b", time.Hour, 2*time.Hour),
+			},
+			expected: []string{"b", "a", "c"},
+		},
+		{
+			name: "pending clusters last by creation time",
+			clusters: []Cluster{
+				pending("pending-old", "", 0),
+				joined("joined-late", 5*time.Hour, 4*time.Hour),
+				pending("pending-new", metav1.ConditionFalse, 3*time.Hour),
+				joined("joined-early", time.Hour, time.Hour),
+				pending("pending-mid", metav1.ConditionUnknown, 2*time.Hour),
+			},
+			expected: []string{"joined-early", "joined-late", "pending-old", "pending-mid", "pending-new"},
+		},
+		{
+			name: "ties break by name",
+			clusters: []Cluster{
+				pending("pending-b", "", time.Hour),
+				joined("joined-b", time.Hour, 0),
+				pending("pending-a", "", time.Hour),
+				joined("joined-a", time.Hour, 2*time.Hour),
+			},
+			expected: []string{"joined-a", "joined-b", "pending-a", "pending-b"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			SortByJoinTime(c.clusters)
+			assertStrings(t, clusterNames(c.clusters), c.expected)
+		})
+	}
+}
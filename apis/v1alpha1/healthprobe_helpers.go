@@ -0,0 +1,130 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+const (
+	// MaxBackoffIntervalSeconds is the upper bound of the health probe backoff interval.
+	MaxBackoffIntervalSeconds int32 = 86400
+
+	// MinBackoffMultiplier and MaxBackoffMultiplier bound the health probe backoff
+	// multiplier.
+	MinBackoffMultiplier = 1.0
+	MaxBackoffMultiplier = 10.0
+
+	probeJitterFraction = 0.1
+)
+
+// DefaultHeartbeatJitterFraction is the fraction of the heartbeat interval a heartbeat
+// may be late by and still be considered on time, when none is specified.
+const DefaultHeartbeatJitterFraction = 0.2
+
+// NextProbeInterval returns the interval to wait before the next health probe after
+// failureCount consecutive failures. The heartbeat interval is multiplied by the backoff
+// multiplier for each failure and capped at the max backoff interval. A random jitter
+// of up to ±10% is applied while backing off, and the result never exceeds the max
+// backoff interval. Use Cluster.NextProbeInterval for a jitter that is stable for a
+// given cluster.
+func NextProbeInterval(hp HealthProbe, failureCount int) time.Duration {
+	return nextProbeInterval(hp, failureCount, rand.Float64())
+}
+
+// NextProbeInterval is like the NextProbeInterval function for the health probe of the
+// cluster, with a jitter seeded by the cluster uid so the probes of different clusters
+// spread out while the interval of a given cluster stays stable.
+func (c *Cluster) NextProbeInterval(failureCount int) time.Duration {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(c.UID))
+	return nextProbeInterval(c.Spec.HealthProbe, failureCount, rand.New(rand.NewSource(int64(h.Sum64()))).Float64())
+}
+
+// nextProbeInterval implements NextProbeInterval with r, a number in [0, 1), picking
+// the jitter.
+func nextProbeInterval(hp HealthProbe, failureCount int, r float64) time.Duration {
+	base := time.Duration(hp.HeartbeatIntervalSeconds) * time.Second
+	if failureCount <= 0 {
+		return base
+	}
+
+	maxSeconds := hp.MaxBackoffIntervalSeconds
+	if maxSeconds <= 0 || maxSeconds > MaxBackoffIntervalSeconds {
+		maxSeconds = MaxBackoffIntervalSeconds
+	}
+	max := time.Duration(maxSeconds) * time.Second
+	multiplier, err := parseMultiplier(hp.BackoffMultiplier)
+	if err != nil || multiplier < MinBackoffMultiplier || multiplier > MaxBackoffMultiplier {
+		multiplier, _ = parseMultiplier(DefaultBackoffMultiplier)
+	}
+
+	interval := exponentialBackoff(base, max, multiplier, failureCount)
+	jittered := time.Duration(float64(interval) * (1 + (r*2-1)*probeJitterFraction))
+	if jittered > max {
+		return max
+	}
+	return jittered
+}
+
+// parseMultiplier parses a backoff multiplier, a finite decimal number.
+func parseMultiplier(s string) (float64, error) {
+	multiplier, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsInf(multiplier, 0) || math.IsNaN(multiplier) {
+		return 0, fmt.Errorf("invalid multiplier %q", s)
+	}
+	return multiplier, nil
+}
+
+// exponentialBackoff returns base multiplied by multiplier attempt times, capped at max.
+func exponentialBackoff(base, max time.Duration, multiplier float64, attempt int) time.Duration {
+	interval := float64(base) * math.Pow(multiplier, float64(attempt))
+	if math.IsInf(interval, 0) || math.IsNaN(interval) || interval > float64(max) {
+		return max
+	}
+	return time.Duration(interval)
+}
+
+// IsSuspended returns true if the health probing is suspended.
+func (h HealthProbe) IsSuspended() bool {
+	return h.Suspend
+}
+
+// HeartbeatDeadline returns the time after which the cluster is unhealthy unless its
+// agent reports another successful heartbeat: the last successful heartbeat plus the
+// heartbeat interval multiplied by 1 plus jitterFraction. It returns false if the agent
+// never reported a successful heartbeat.
+func (c *Cluster) HeartbeatDeadline(jitterFraction float64) (time.Time, bool) {
+	if c.Status.AgentStatus == nil || c.Status.AgentStatus.LastSuccessfulHeartbeatTime == nil {
+		return time.Time{}, false
+	}
+	interval := time.Duration(c.Spec.HealthProbe.HeartbeatIntervalSeconds) * time.Second
+	return c.Status.AgentStatus.LastSuccessfulHeartbeatTime.Add(time.Duration(float64(interval) * (1 + jitterFraction))), true
+}
+
+// IsHealthyAt returns true if the cluster is healthy at now, that is if the heartbeat
+// deadline with DefaultHeartbeatJitterFraction has not passed. Unlike IsHealthy, it
+// does not trust a Healthy condition gone stale since its agent stopped reporting.
+// While the health probe is suspended, the Healthy condition is used.
+func (c *Cluster) IsHealthyAt(now time.Time) bool {
+	if c.Spec.HealthProbe.IsSuspended() {
+		return c.IsHealthy()
+	}
+	deadline, ok := c.HeartbeatDeadline(DefaultHeartbeatJitterFraction)
+	return ok && !now.After(deadline)
+}
+
+// ProbeType returns HTTPProbe if the health probe has an HTTP endpoint and
+// HeartbeatProbe otherwise.
+func (h HealthProbe) ProbeType() HealthProbeType {
+	if h.HTTPEndpoint != "" {
+		return HTTPProbe
+	}
+	return HeartbeatProbe
+}
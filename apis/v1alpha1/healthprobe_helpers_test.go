@@ -0,0 +1,168 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestNextProbeInterval(t *testing.T) {
+	hp := HealthProbe{HeartbeatIntervalSeconds: 60, BackoffMultiplier: "2", MaxBackoffIntervalSeconds: 600}
+
+	cases := []struct {
+		name         string
+		hp           HealthProbe
+		failureCount int
+		r            float64
+		expected     time.Duration
+	}{
+		{
+			name:     "no failures",
+			hp:       hp,
+			r:        0,
+			expected: time.Minute,
+		},
+		{
+			name:         "backoff",
+			hp:           hp,
+			failureCount: 2,
+			r:            0.5,
+			expected:     4 * time.Minute,
+		},
+		{
+			name:         "default multiplier",
+			hp:           HealthProbe{HeartbeatIntervalSeconds: 60},
+			failureCount: 2,
+			r:            0.5,
+			expected:     135 * time.Second,
+		},
+		{
+			name:         "invalid multiplier",
+			hp:           HealthProbe{HeartbeatIntervalSeconds: 60, BackoffMultiplier: "Inf"},
+			failureCount: 2,
+			r:            0.5,
+			expected:     135 * time.Second,
+		},
+		{
+			name:         "capped at max",
+			hp:           hp,
+			failureCount: 5,
+			r:            0.5,
+			expected:     10 * time.Minute,
+		},
+		{
+			name:         "capped at max after jitter",
+			hp:           HealthProbe{HeartbeatIntervalSeconds: 60, BackoffMultiplier: "2", MaxBackoffIntervalSeconds: 250},
+			failureCount: 2,
+			r:            0.99,
+			expected:     250 * time.Second,
+		},
+		{
+			name:         "lowest jitter",
+			hp:           hp,
+			failureCount: 1,
+			r:            0,
+			expected:     108 * time.Second,
+		},
+		{
+			name:         "max backoff interval above the limit",
+			hp:           HealthProbe{HeartbeatIntervalSeconds: 60, BackoffMultiplier: "10", MaxBackoffIntervalSeconds: 100000},
+			failureCount: 10,
+			r:            0.5,
+			expected:     time.Duration(MaxBackoffIntervalSeconds) * time.Second,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := nextProbeInterval(c.hp, c.failureCount, c.r); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNextProbeIntervalJitter(t *testing.T) {
+	hp := HealthProbe{HeartbeatIntervalSeconds: 60, BackoffMultiplier: "2"}
+	expected := 4 * time.Minute
+	low, high := time.Duration(float64(expected)*0.9), time.Duration(float64(expected)*1.1)
+
+	for i := 0; i < 100; i++ {
+		if actual := NextProbeInterval(hp, 2); actual < low || actual > high {
+			t.Fatalf("expected an interval between %v and %v, got %v", low, high, actual)
+		}
+	}
+
+	cluster := &Cluster{Spec: ClusterSpec{HealthProbe: hp}}
+	cluster.UID = types.UID("6a4f2c2e-8f4b-4c4e-9a38-8f1d1d6b2b4e")
+	interval := cluster.NextProbeInterval(2)
+	if interval < low || interval > high {
+		t.Errorf("expected an interval between %v and %v, got %v", low, high, interval)
+	}
+	if again := cluster.NextProbeInterval(2); again != interval {
+		t.Errorf("expected a stable interval for the cluster, got %v and %v", interval, again)
+	}
+}
+
+func TestProbeType(t *testing.T) {
+	if actual := (HealthProbe{HeartbeatIntervalSeconds: 60}).ProbeType(); actual != HeartbeatProbe {
+		t.Errorf("expected %s, got %s", HeartbeatProbe, actual)
+	}
+	if actual := (HealthProbe{HTTPEndpoint: "https://cluster.example.com/healthz"}).ProbeType(); actual != HTTPProbe {
+		t.Errorf("expected %s, got %s", HTTPProbe, actual)
+	}
+}
+
+func TestIsHealthyAt(t *testing.T) {
+	now := time.Now()
+	healthy := newCondition(ClusterConditionHealthy, metav1.ConditionTrue)
+	unhealthy := newCondition(ClusterConditionHealthy, metav1.ConditionFalse)
+
+	cases := []struct {
+		name      string
+		cluster   Cluster
+		suspended bool
+		expected  bool
+	}{
+		{
+			name:     "heartbeat on time",
+			cluster:  newHeartbeatCluster("a", now.Add(-time.Minute), unhealthy),
+			expected: true,
+		},
+		{
+			name:     "heartbeat late within jitter",
+			cluster:  newHeartbeatCluster("a", now.Add(-72*time.Second)),
+			expected: true,
+		},
+		{
+			name:    "heartbeat late beyond jitter",
+			cluster: newHeartbeatCluster("a", now.Add(-73*time.Second), healthy),
+		},
+		{
+			name:    "no heartbeat",
+			cluster: newCluster("a", healthy),
+		},
+		{
+			name:      "suspended healthy",
+			cluster:   newCluster("a", healthy),
+			suspended: true,
+			expected:  true,
+		},
+		{
+			name:      "suspended unhealthy",
+			cluster:   newHeartbeatCluster("a", now, unhealthy),
+			suspended: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.cluster.Spec.HealthProbe.Suspend = c.suspended
+			if actual := c.cluster.IsHealthyAt(now); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package v1alpha1
+
+import (
+	"strings"
+)
+
+// ClusterIdentity identifies a cluster across systems.
+// +kubebuilder:object:generate=false
+type ClusterIdentity struct {
+	// ClusterSet is the name of the cluster set of the cluster.
+	ClusterSet string
+	// ClusterID is the unique identifier of the cluster.
+	ClusterID string
+	// Name is the name of the cluster.
+	Name string
+}
+
+// Identity returns the identity of the cluster. The cluster set is read from the
+// clusterset.k8s.io property and falls back to the namespace of the cluster. The
+// cluster ID is read from the id.k8s.io property and falls back to the uid of the
+// cluster. Fields are empty if neither source is set.
+func (c *Cluster) Identity() ClusterIdentity {
+	clusterSet, ok := c.GetProperty(PropertyClusterSet)
+	if !ok || clusterSet == "" {
+		clusterSet = c.Namespace
+	}
+	clusterID, ok := c.GetProperty(PropertyClusterID)
+	if !ok || clusterID == "" {
+		clusterID = string(c.UID)
+	}
+	return ClusterIdentity{
+		ClusterSet: clusterSet,
+		ClusterID:  clusterID,
+		Name:       c.Name,
+	}
+}
+
+// String returns the canonical key of the identity, the cluster set, cluster ID and
+// name joined by "/".
+func (i ClusterIdentity) String() string {
+	return strings.Join([]string{i.ClusterSet, i.ClusterID, i.Name}, "/")
+}
@@ -0,0 +1,64 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestIdentity(t *testing.T) {
+	cases := []struct {
+		name       string
+		namespace  string
+		uid        types.UID
+		properties []Property
+		expected   ClusterIdentity
+		key        string
+	}{
+		{
+			name:       "from properties",
+			namespace:  "fleet",
+			uid:        "uid1",
+			properties: []Property{{Name: PropertyClusterSet, Value: "set1"}, {Name: PropertyClusterID, Value: "id1"}},
+			expected:   ClusterIdentity{ClusterSet: "set1", ClusterID: "id1", Name: "cluster1"},
+			key:        "set1/id1/cluster1",
+		},
+		{
+			name:      "falls back to namespace and uid",
+			namespace: "fleet",
+			uid:       "uid1",
+			expected:  ClusterIdentity{ClusterSet: "fleet", ClusterID: "uid1", Name: "cluster1"},
+			key:       "fleet/uid1/cluster1",
+		},
+		{
+			name:       "empty properties fall back",
+			namespace:  "fleet",
+			uid:        "uid1",
+			properties: []Property{{Name: PropertyClusterSet, Value: ""}, {Name: PropertyClusterID, Value: ""}},
+			expected:   ClusterIdentity{ClusterSet: "fleet", ClusterID: "uid1", Name: "cluster1"},
+			key:        "fleet/uid1/cluster1",
+		},
+		{
+			name:     "missing fields are empty",
+			expected: ClusterIdentity{Name: "cluster1"},
+			key:      "//cluster1",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.Namespace = c.namespace
+			cluster.UID = c.uid
+			cluster.Status.Properties = c.properties
+
+			identity := cluster.Identity()
+			if identity != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, identity)
+			}
+			if key := identity.String(); key != c.key {
+				t.Errorf("expected key %q, got %q", c.key, key)
+			}
+		})
+	}
+}
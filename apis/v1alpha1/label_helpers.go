@@ -0,0 +1,72 @@
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const labelValueHashLength = 8
+
+// SyncPropertiesToLabels copies the named properties into labels keyed by prefix and
+// the property name, so they can be matched by label selectors. Values are sanitized to
+// valid label values, and values longer than 63 characters are truncated and suffixed
+// with a hash of the full value. The label of a property missing from the status is
+// removed. Names that do not form a valid label key are skipped. It returns true if the
+// labels were changed.
+func (c *Cluster) SyncPropertiesToLabels(prefix string, names []string) bool {
+	changed := false
+	for _, name := range names {
+		key := prefix + name
+		if len(validation.IsQualifiedName(key)) > 0 {
+			continue
+		}
+
+		value, ok := c.GetProperty(name)
+		if !ok {
+			if _, exists := c.Labels[key]; exists {
+				delete(c.Labels, key)
+				changed = true
+			}
+			continue
+		}
+
+		value = sanitizeLabelValue(value)
+		if current, exists := c.Labels[key]; exists && current == value {
+			continue
+		}
+		if c.Labels == nil {
+			c.Labels = map[string]string{}
+		}
+		c.Labels[key] = value
+		changed = true
+	}
+	return changed
+}
+
+// sanitizeLabelValue replaces the characters not allowed in a label value with "-" and
+// trims the value so it begins and ends with an alphanumeric character. Values longer
+// than the label value limit are truncated and suffixed with a hash of the original.
+func sanitizeLabelValue(value string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if isAlphanumeric(r) || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '-'
+	}, value)
+	sanitized = strings.TrimFunc(sanitized, func(r rune) bool { return !isAlphanumeric(r) })
+
+	if len(sanitized) <= validation.LabelValueMaxLength {
+		return sanitized
+	}
+	sum := sha256.Sum256([]byte(value))
+	hash := hex.EncodeToString(sum[:])[:labelValueHashLength]
+	truncated := strings.TrimFunc(sanitized[:validation.LabelValueMaxLength-labelValueHashLength-1], func(r rune) bool { return !isAlphanumeric(r) })
+	return truncated + "-" + hash
+}
+
+func isAlphanumeric(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
@@ -0,0 +1,107 @@
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestSyncPropertiesToLabels(t *testing.T) {
+	const prefix = "property.example.com/"
+	longValue := strings.Repeat("a", 70)
+	sum := sha256.Sum256([]byte(longValue))
+	longValueHash := hex.EncodeToString(sum[:])[:8]
+
+	cases := []struct {
+		name            string
+		properties      []Property
+		labels          map[string]string
+		names           []string
+		expectedLabels  map[string]string
+		expectedChanged bool
+	}{
+		{
+			name:           "no names",
+			properties:     []Property{{Name: "region", Value: "us-east"}},
+			expectedLabels: map[string]string{},
+		},
+		{
+			name:            "copied",
+			properties:      []Property{{Name: "region", Value: "us-east"}, {Name: "zone", Value: "a"}},
+			names:           []string{"region"},
+			expectedLabels:  map[string]string{prefix + "region": "us-east"},
+			expectedChanged: true,
+		},
+		{
+			name:           "unchanged",
+			properties:     []Property{{Name: "region", Value: "us-east"}},
+			labels:         map[string]string{prefix + "region": "us-east"},
+			names:          []string{"region"},
+			expectedLabels: map[string]string{prefix + "region": "us-east"},
+		},
+		{
+			name:            "missing property removes the label",
+			labels:          map[string]string{prefix + "region": "us-east", "other": "kept"},
+			names:           []string{"region"},
+			expectedLabels:  map[string]string{"other": "kept"},
+			expectedChanged: true,
+		},
+		{
+			name:            "value is sanitized",
+			properties:      []Property{{Name: "region", Value: " us east/1 "}},
+			names:           []string{"region"},
+			expectedLabels:  map[string]string{prefix + "region": "us-east-1"},
+			expectedChanged: true,
+		},
+		{
+			name:            "long value is hash suffixed",
+			properties:      []Property{{Name: "region", Value: longValue}},
+			names:           []string{"region"},
+			expectedLabels:  map[string]string{prefix + "region": strings.Repeat("a", 54) + "-" + longValueHash},
+			expectedChanged: true,
+		},
+		{
+			name:           "invalid label key is skipped",
+			properties:     []Property{{Name: "not a name", Value: "value"}},
+			names:          []string{"not a name"},
+			expectedLabels: map[string]string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.Status.Properties = c.properties
+			cluster.Labels = c.labels
+
+			if changed := cluster.SyncPropertiesToLabels(prefix, c.names); changed != c.expectedChanged {
+				t.Errorf("expected changed %v, got %v", c.expectedChanged, changed)
+			}
+			if len(cluster.Labels) != len(c.expectedLabels) {
+				t.Fatalf("expected labels %v, got %v", c.expectedLabels, cluster.Labels)
+			}
+			for key, value := range c.expectedLabels {
+				if cluster.Labels[key] != value {
+					t.Errorf("expected label %s=%s, got %v", key, value, cluster.Labels)
+				}
+				if errs := validation.IsValidLabelValue(cluster.Labels[key]); len(errs) > 0 {
+					t.Errorf("expected a valid label value, got %q: %v", cluster.Labels[key], errs)
+				}
+			}
+		})
+	}
+}
+
+func TestSanitizeLabelValueHash(t *testing.T) {
+	a := sanitizeLabelValue(strings.Repeat("a", 70) + "x")
+	b := sanitizeLabelValue(strings.Repeat("a", 70) + "y")
+	if len(a) != validation.LabelValueMaxLength || len(b) != validation.LabelValueMaxLength {
+		t.Errorf("expected values of %d characters, got %q and %q", validation.LabelValueMaxLength, a, b)
+	}
+	if a == b {
+		t.Errorf("expected long values with different tails to differ, got %q", a)
+	}
+}
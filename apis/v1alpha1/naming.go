@@ -0,0 +1,66 @@
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const clusterNameHashLength = 8
+
+// ClusterNamingStrategy describes how GenerateClusterName builds cluster names.
+// +kubebuilder:object:generate=false
+type ClusterNamingStrategy struct {
+	// Prefix is prepended to the generated hash. It is sanitized so the result is a
+	// valid DNS label: letters are lowercased, other characters that are not allowed
+	// in a DNS label are replaced with '-' and leading '-' are trimmed.
+	Prefix string
+
+	// MaxLength is the maximum length of the generated name. Zero or a value greater
+	// than 63 means the DNS label limit of 63.
+	MaxLength int
+}
+
+// GenerateClusterName returns a deterministic name built from the strategy prefix and
+// the first 8 hex characters of the SHA-256 hash of seed. When the name exceeds
+// MaxLength, the prefix is shortened first so the hash is kept intact. If the result is
+// still not a valid DNS label, the hash alone is returned.
+func GenerateClusterName(strategy ClusterNamingStrategy, seed string) string {
+	maxLength := strategy.MaxLength
+	if maxLength <= 0 || maxLength > validation.DNS1123LabelMaxLength {
+		maxLength = validation.DNS1123LabelMaxLength
+	}
+
+	sum := sha256.Sum256([]byte(seed))
+	hash := hex.EncodeToString(sum[:])[:clusterNameHashLength]
+	if maxLength <= len(hash) {
+		return hash[:maxLength]
+	}
+
+	prefix := sanitizeNamePrefix(strategy.Prefix)
+	if len(prefix)+len(hash) > maxLength {
+		prefix = prefix[:maxLength-len(hash)]
+	}
+	if name := prefix + hash; len(validation.IsDNS1123Label(name)) == 0 {
+		return name
+	}
+	return hash
+}
+
+// sanitizeNamePrefix lowercases prefix, replaces the characters not allowed in a DNS
+// label with '-' and trims the leading '-', since a DNS label must start with an
+// alphanumeric character.
+func sanitizeNamePrefix(prefix string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return '-'
+	}, prefix)
+	return strings.TrimLeft(sanitized, "-")
+}
@@ -0,0 +1,92 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestGenerateClusterName(t *testing.T) {
+	cases := []struct {
+		name     string
+		strategy ClusterNamingStrategy
+		seed     string
+		expected string
+	}{
+		{
+			name:     "no prefix",
+			seed:     "seed",
+			expected: "19b25856",
+		},
+		{
+			name:     "prefix",
+			strategy: ClusterNamingStrategy{Prefix: "cluster-"},
+			seed:     "seed",
+			expected: "cluster-19b25856",
+		},
+		{
+			name:     "prefix is lowercased",
+			strategy: ClusterNamingStrategy{Prefix: "Cluster-"},
+			seed:     "seed",
+			expected: "cluster-19b25856",
+		},
+		{
+			name:     "invalid characters are replaced",
+			strategy: ClusterNamingStrategy{Prefix: "east_us.prod/"},
+			seed:     "seed",
+			expected: "east-us-prod-19b25856",
+		},
+		{
+			name:     "leading dashes are trimmed",
+			strategy: ClusterNamingStrategy{Prefix: "--_cluster-"},
+			seed:     "seed",
+			expected: "cluster-19b25856",
+		},
+		{
+			name:     "prefix is truncated",
+			strategy: ClusterNamingStrategy{Prefix: "cluster-", MaxLength: 12},
+			seed:     "seed",
+			expected: "clus19b25856",
+		},
+		{
+			name:     "hash is truncated",
+			strategy: ClusterNamingStrategy{Prefix: "cluster-", MaxLength: 4},
+			seed:     "seed",
+			expected: "19b2",
+		},
+		{
+			name:     "max length is capped",
+			strategy: ClusterNamingStrategy{Prefix: strings.Repeat("a", 70), MaxLength: 100},
+			seed:     "seed",
+			expected: strings.Repeat("a", 55) + "19b25856",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			name := GenerateClusterName(c.strategy, c.seed)
+			if name != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, name)
+			}
+			if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+				t.Errorf("expected a DNS label, got %q: %v", name, errs)
+			}
+			if again := GenerateClusterName(c.strategy, c.seed); again != name {
+				t.Errorf("expected the same name for the same seed, got %q and %q", name, again)
+			}
+		})
+	}
+}
+
+func TestGenerateClusterNameUniqueness(t *testing.T) {
+	strategy := ClusterNamingStrategy{Prefix: "cluster-"}
+	seen := map[string]string{}
+	for _, seed := range []string{"a", "b", "c", "cluster1", "cluster2", "cluster-1"} {
+		name := GenerateClusterName(strategy, seed)
+		if other, ok := seen[name]; ok {
+			t.Errorf("expected unique names, seeds %q and %q both generated %q", other, seed, name)
+		}
+		seen[name] = seed
+	}
+}
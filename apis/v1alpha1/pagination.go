@@ -0,0 +1,43 @@
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HasMore returns true if the list is a page followed by more clusters.
+func HasMore(list ClusterList) bool {
+	return list.Continue != ""
+}
+
+// NextListOptions returns the options listing the page following the list.
+func NextListOptions(list ClusterList, limit int64) metav1.ListOptions {
+	return metav1.ListOptions{
+		Limit:    limit,
+		Continue: list.Continue,
+	}
+}
+
+// FlattenPages calls lister for every page of at most limit clusters and returns the
+// clusters of all pages. It stops at the first error returned by lister or when the
+// context is done.
+func FlattenPages(ctx context.Context, lister func(context.Context, metav1.ListOptions) (*ClusterList, error), limit int64) ([]Cluster, error) {
+	var clusters []Cluster
+	opts := metav1.ListOptions{Limit: limit}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		list, err := lister(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, list.Items...)
+		if !HasMore(*list) {
+			return clusters, nil
+		}
+		opts = NextListOptions(*list, limit)
+	}
+}
@@ -0,0 +1,120 @@
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// pagedLister returns a lister serving the pages in order, with continue tokens linking
+// them, and recording the options of every call.
+func pagedLister(pages [][]Cluster, calls *[]metav1.ListOptions) func(context.Context, metav1.ListOptions) (*ClusterList, error) {
+	return func(_ context.Context, opts metav1.ListOptions) (*ClusterList, error) {
+		*calls = append(*calls, opts)
+		i := len(*calls) - 1
+		list := &ClusterList{Items: pages[i]}
+		if i < len(pages)-1 {
+			list.Continue = string(rune('a' + i))
+		}
+		return list, nil
+	}
+}
+
+func TestFlattenPages(t *testing.T) {
+	cases := []struct {
+		name              string
+		pages             [][]Cluster
+		expectedNames     []string
+		expectedContinues []string
+	}{
+		{
+			name:              "single page",
+			pages:             [][]Cluster{{newCluster("a"), newCluster("b")}},
+			expectedNames:     []string{"a", "b"},
+			expectedContinues: []string{""},
+		},
+		{
+			name:              "multiple pages",
+			pages:             [][]Cluster{{newCluster("a"), newCluster("b")}, {newCluster("c"), newCluster("d")}, {newCluster("e")}},
+			expectedNames:     []string{"a", "b", "c", "d", "e"},
+			expectedContinues: []string{"", "a", "b"},
+		},
+		{
+			name:              "empty last page",
+			pages:             [][]Cluster{{newCluster("a"), newCluster("b")}, {}},
+			expectedNames:     []string{"a", "b"},
+			expectedContinues: []string{"", "a"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var calls []metav1.ListOptions
+			clusters, err := FlattenPages(context.Background(), pagedLister(c.pages, &calls), 2)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertStrings(t, clusterNames(clusters), c.expectedNames)
+
+			if len(calls) != len(c.expectedContinues) {
+				t.Fatalf("expected %d calls, got %v", len(c.expectedContinues), calls)
+			}
+			for i, opts := range calls {
+				if opts.Limit != 2 || opts.Continue != c.expectedContinues[i] {
+					t.Errorf("expected call %d with limit 2 and continue %q, got %v", i, c.expectedContinues[i], opts)
+				}
+			}
+		})
+	}
+}
+
+func TestFlattenPagesError(t *testing.T) {
+	listErr := errors.New("list failed")
+	calls := 0
+	lister := func(_ context.Context, opts metav1.ListOptions) (*ClusterList, error) {
+		calls++
+		if opts.Continue != "" {
+			return nil, listErr
+		}
+		return &ClusterList{ListMeta: metav1.ListMeta{Continue: "a"}, Items: []Cluster{newCluster("a")}}, nil
+	}
+
+	if _, err := FlattenPages(context.Background(), lister, 1); !errors.Is(err, listErr) {
+		t.Errorf("expected %v, got %v", listErr, err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestFlattenPagesContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	lister := func(_ context.Context, _ metav1.ListOptions) (*ClusterList, error) {
+		calls++
+		cancel()
+		return &ClusterList{ListMeta: metav1.ListMeta{Continue: "a"}, Items: []Cluster{newCluster("a")}}, nil
+	}
+
+	if _, err := FlattenPages(ctx, lister, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	if calls != 1 {
+		t.Errorf("expected the iteration to stop after 1 call, got %d", calls)
+	}
+}
+
+func TestNextListOptions(t *testing.T) {
+	list := ClusterList{ListMeta: metav1.ListMeta{Continue: "token"}}
+	if !HasMore(list) {
+		t.Errorf("expected more pages")
+	}
+	if opts := NextListOptions(list, 10); opts.Limit != 10 || opts.Continue != "token" {
+		t.Errorf("expected limit 10 and continue token, got %v", opts)
+	}
+	if HasMore(ClusterList{}) {
+		t.Errorf("expected no more pages")
+	}
+}
@@ -0,0 +1,143 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+func TestStrategicMergePatch(t *testing.T) {
+	kubeConfig := AccessObjectRef{Type: AccessTypeKubeConfig, Resource: "secrets", Name: "kubeconfig", Namespace: "fleet"}
+	token := AccessObjectRef{Type: AccessTypeKubeConfig, Resource: "secrets", Name: "token", Namespace: "fleet"}
+	vault := AccessObjectRef{Type: AccessTypeKubeConfig, ExternalSecretRef: &ExternalSecretRef{Provider: ExternalSecretProviderVault, Path: "cluster1"}}
+	serviceAccount := AccessObjectRef{Type: AccessTypeServiceAccount, ServiceAccountRef: &ServiceAccountAccessConfig{ServiceAccountName: "fleet"}}
+	maintenance := Taint{Key: "example.com/maintenance", Effect: TaintEffectNoSelect}
+	preferMaintenance := Taint{Key: "example.com/maintenance", Effect: TaintEffectPreferNoSelect}
+	unreachable := Taint{Key: "example.com/unreachable", Effect: TaintEffectNoSelectIfNew}
+
+	cases := []struct {
+		name           string
+		refs           []AccessObjectRef
+		taints         []Taint
+		patch          map[string]interface{}
+		expectedRefs   []AccessObjectRef
+		expectedTaints []Taint
+	}{
+		{
+			name:   "adding a taint keeps existing taints",
+			refs:   []AccessObjectRef{kubeConfig, token},
+			taints: []Taint{maintenance},
+			patch: map[string]interface{}{"spec": map[string]interface{}{
+				"taints": []interface{}{
+					map[string]interface{}{"key": unreachable.Key, "effect": string(unreachable.Effect)},
+				},
+			}},
+			expectedRefs:   []AccessObjectRef{kubeConfig, token},
+			expectedTaints: []Taint{unreachable, maintenance},
+		},
+		{
+			name:   "deleting a taint keeps other taints",
+			refs:   []AccessObjectRef{kubeConfig, token},
+			taints: []Taint{maintenance, unreachable},
+			patch: map[string]interface{}{"spec": map[string]interface{}{
+				"taints": []interface{}{
+					map[string]interface{}{"key": maintenance.Key, "$patch": "delete"},
+				},
+			}},
+			expectedRefs:   []AccessObjectRef{kubeConfig, token},
+			expectedTaints: []Taint{unreachable},
+		},
+		{
+			// Strategic merge patch merges taints by key only, so a taint with a new effect
+			// replaces the existing taint of its key instead of being added next to it.
+			name:   "adding a taint with the key of an existing taint replaces it",
+			refs:   []AccessObjectRef{kubeConfig},
+			taints: []Taint{maintenance},
+			patch: map[string]interface{}{"spec": map[string]interface{}{
+				"taints": []interface{}{
+					map[string]interface{}{"key": preferMaintenance.Key, "effect": string(preferMaintenance.Effect)},
+				},
+			}},
+			expectedRefs:   []AccessObjectRef{kubeConfig},
+			expectedTaints: []Taint{preferMaintenance},
+		},
+		{
+			name:   "patching access refs replaces the list",
+			refs:   []AccessObjectRef{kubeConfig, token},
+			taints: []Taint{maintenance},
+			patch: map[string]interface{}{"spec": map[string]interface{}{
+				"accessObjectRef": []interface{}{
+					map[string]interface{}{"type": string(token.Type), "resource": token.Resource, "name": token.Name, "namespace": "other"},
+				},
+			}},
+			expectedRefs: []AccessObjectRef{func() AccessObjectRef {
+				ref := token
+				ref.Namespace = "other"
+				return ref
+			}()},
+			expectedTaints: []Taint{maintenance},
+		},
+		{
+			name:   "access refs without name are kept apart",
+			refs:   []AccessObjectRef{kubeConfig},
+			taints: []Taint{maintenance},
+			patch: map[string]interface{}{"spec": map[string]interface{}{
+				"accessObjectRef": []interface{}{
+					map[string]interface{}{"type": string(vault.Type), "externalSecretRef": map[string]interface{}{
+						"provider": vault.ExternalSecretRef.Provider, "path": vault.ExternalSecretRef.Path,
+					}},
+					map[string]interface{}{"type": string(serviceAccount.Type), "serviceAccountRef": map[string]interface{}{
+						"serviceAccountName": serviceAccount.ServiceAccountRef.ServiceAccountName,
+					}},
+				},
+			}},
+			expectedRefs:   []AccessObjectRef{vault, serviceAccount},
+			expectedTaints: []Taint{maintenance},
+		},
+		{
+			name:   "updating taints keeps access refs without name",
+			refs:   []AccessObjectRef{vault, serviceAccount},
+			taints: []Taint{maintenance},
+			patch: map[string]interface{}{"spec": map[string]interface{}{
+				"taints": []interface{}{
+					map[string]interface{}{"key": maintenance.Key, "$patch": "delete"},
+				},
+			}},
+			expectedRefs: []AccessObjectRef{vault, serviceAccount},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.Spec.AccessObjectRefs = c.refs
+			cluster.Spec.Taints = c.taints
+
+			original, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&cluster)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			patchMeta, err := strategicpatch.NewPatchMetaFromStruct(&Cluster{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			merged, err := strategicpatch.MergeStrategicMergeMapPatchUsingLookupPatchMeta(patchMeta, original, c.patch)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var patched Cluster
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(merged, &patched); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(patched.Spec.AccessObjectRefs, c.expectedRefs) {
+				t.Errorf("expected access refs %v, got %v", c.expectedRefs, patched.Spec.AccessObjectRefs)
+			}
+			if !equality.Semantic.DeepEqual(patched.Spec.Taints, c.expectedTaints) {
+				t.Errorf("expected taints %v, got %v", c.expectedTaints, patched.Spec.Taints)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ProviderAWS is the provider of AWS provider IDs, aws:///<zone>/<instance-id>.
+	ProviderAWS = "aws"
+	// ProviderGCE is the provider of GCE provider IDs, gce://<project>/<zone>/<instance>.
+	ProviderGCE = "gce"
+	// ProviderAzure is the provider of Azure provider IDs,
+	// azure:///subscriptions/<subscription>/resourceGroups/<group>/providers/<type>/<name>.
+	ProviderAzure = "azure"
+)
+
+// ParseProviderID parses a provider ID in one of the well-known formats. For AWS and
+// GCE the region is the zone of the instance. Azure provider IDs carry no location, so
+// the region is empty and the instance ID is the full resource ID. An empty provider ID
+// is not an error and returns empty fields.
+func ParseProviderID(id string) (provider, region, instanceID string, err error) {
+	if id == "" {
+		return "", "", "", nil
+	}
+
+	provider, rest, found := strings.Cut(id, "://")
+	if !found {
+		return "", "", "", fmt.Errorf("invalid provider ID %q: missing provider prefix", id)
+	}
+
+	var parts []string
+	switch provider {
+	case ProviderAWS:
+		parts = strings.Split(strings.TrimPrefix(rest, "/"), "/")
+		if len(parts) != 2 {
+			return "", "", "", fmt.Errorf("invalid AWS provider ID %q: expected aws:///<zone>/<instance-id>", id)
+		}
+		region, instanceID = parts[0], parts[1]
+	case ProviderGCE:
+		parts = strings.Split(rest, "/")
+		if len(parts) != 3 {
+			return "", "", "", fmt.Errorf("invalid GCE provider ID %q: expected gce://<project>/<zone>/<instance>", id)
+		}
+		region, instanceID = parts[1], parts[2]
+	case ProviderAzure:
+		if !strings.HasPrefix(rest, "/subscriptions/") {
+			return "", "", "", fmt.Errorf("invalid Azure provider ID %q: expected azure:///subscriptions/...", id)
+		}
+		instanceID = rest
+	default:
+		return "", "", "", fmt.Errorf("invalid provider ID %q: unknown provider %q", id, provider)
+	}
+
+	for _, part := range parts {
+		if part == "" {
+			return "", "", "", fmt.Errorf("invalid provider ID %q: empty segment", id)
+		}
+	}
+	return provider, region, instanceID, nil
+}
@@ -0,0 +1,92 @@
+package v1alpha1
+
+import "testing"
+
+func TestParseProviderID(t *testing.T) {
+	cases := []struct {
+		name               string
+		id                 string
+		expectedProvider   string
+		expectedRegion     string
+		expectedInstanceID string
+		expectError        bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:               "aws",
+			id:                 "aws:///us-east-1a/i-1234567890abcdef0",
+			expectedProvider:   ProviderAWS,
+			expectedRegion:     "us-east-1a",
+			expectedInstanceID: "i-1234567890abcdef0",
+		},
+		{
+			name:               "gce",
+			id:                 "gce://my-project/us-central1-a/instance-1",
+			expectedProvider:   ProviderGCE,
+			expectedRegion:     "us-central1-a",
+			expectedInstanceID: "instance-1",
+		},
+		{
+			name:               "azure",
+			id:                 "azure:///subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm",
+			expectedProvider:   ProviderAzure,
+			expectedInstanceID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm",
+		},
+		{
+			name:        "missing provider prefix",
+			id:          "i-1234567890abcdef0",
+			expectError: true,
+		},
+		{
+			name:        "unknown provider",
+			id:          "openstack:///instance",
+			expectError: true,
+		},
+		{
+			name:        "aws without zone",
+			id:          "aws:///i-1234567890abcdef0",
+			expectError: true,
+		},
+		{
+			name:        "aws with empty zone",
+			id:          "aws:////i-1234567890abcdef0",
+			expectError: true,
+		},
+		{
+			name:        "gce without project",
+			id:          "gce://us-central1-a/instance-1",
+			expectError: true,
+		},
+		{
+			name:        "gce with empty instance",
+			id:          "gce://my-project/us-central1-a/",
+			expectError: true,
+		},
+		{
+			name:        "azure without subscription",
+			id:          "azure:///resourceGroups/rg",
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			provider, region, instanceID, err := ParseProviderID(c.id)
+			if c.expectError {
+				if err == nil {
+					t.Errorf("expected an error, got (%q, %q, %q)", provider, region, instanceID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if provider != c.expectedProvider || region != c.expectedRegion || instanceID != c.expectedInstanceID {
+				t.Errorf("expected (%q, %q, %q), got (%q, %q, %q)",
+					c.expectedProvider, c.expectedRegion, c.expectedInstanceID, provider, region, instanceID)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Usable returns the allocatable resources minus the reserved resources. Quantities
+// never drop below zero.
+func (r Resources) Usable() ResourceList {
+	usable := ResourceList{}
+	for name, allocatable := range r.Allocatable {
+		q := allocatable.DeepCopy()
+		if reserved, ok := r.Reserved[name]; ok {
+			q.Sub(reserved)
+		}
+		if q.Sign() < 0 {
+			q = resource.Quantity{Format: q.Format}
+		}
+		usable[name] = q
+	}
+	return usable
+}
+
+// names returns the resource names in the list in sorted order.
+func (r ResourceList) names() []ResourceName {
+	names := make([]ResourceName, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// MaxResourceSnapshots is the maximum number of snapshots kept in the resources history.
+const MaxResourceSnapshots = 5
+
+// RecordResourceSnapshot appends a snapshot of r taken at now to the resources history,
+// dropping the oldest snapshots so that at most max are kept. A max outside of
+// [1, MaxResourceSnapshots] is treated as MaxResourceSnapshots.
+func (s *ClusterStatus) RecordResourceSnapshot(r Resources, now time.Time, max int) {
+	if max <= 0 || max > MaxResourceSnapshots {
+		max = MaxResourceSnapshots
+	}
+
+	s.ResourcesHistory = append(s.ResourcesHistory, ResourceSnapshot{
+		Time:      metav1.NewTime(now),
+		Resources: *r.DeepCopy(),
+	})
+	if len(s.ResourcesHistory) > max {
+		s.ResourcesHistory = s.ResourcesHistory[len(s.ResourcesHistory)-max:]
+	}
+}
+
+// CapacityTrend compares the capacity of the named resource in the oldest and newest
+// snapshots of the resources history. It returns 1 if the capacity increased, -1 if it
+// decreased and 0 if it is flat or cannot be determined.
+func (s *ClusterStatus) CapacityTrend(name ResourceName) int {
+	if len(s.ResourcesHistory) < 2 {
+		return 0
+	}
+
+	oldest, ok := s.ResourcesHistory[0].Resources.Capacity[name]
+	if !ok {
+		return 0
+	}
+	newest, ok := s.ResourcesHistory[len(s.ResourcesHistory)-1].Resources.Capacity[name]
+	if !ok {
+		return 0
+	}
+	return newest.Cmp(oldest)
+}
+
+// Scale returns a copy of the resource list with every quantity multiplied by factor.
+// It returns nil for a negative factor or a scaled quantity out of range; use TryScale
+// to get an error instead.
+func (r ResourceList) Scale(factor float64) ResourceList {
+	scaled, err := r.TryScale(factor)
+	if err != nil {
+		return nil
+	}
+	return scaled
+}
+
+// TryScale returns a copy of the resource list with every quantity multiplied by
+// factor, or an error if factor is negative or a scaled quantity does not fit in an
+// int64. Scaled quantities keep the format of the original; whole values are kept
+// exact, binary quantities such as memory are rounded to the nearest unit and other
+// fractional values to the nearest milli unit, or to the nearest unit when too large
+// to be expressed in milli units.
+func (r ResourceList) TryScale(factor float64) (ResourceList, error) {
+	if factor < 0 || math.IsNaN(factor) || math.IsInf(factor, 0) {
+		return nil, fmt.Errorf("invalid scale factor %v", factor)
+	}
+
+	scaled := make(ResourceList, len(r))
+	for name, q := range r {
+		v := q.AsApproximateFloat64() * factor
+		switch {
+		case (v == math.Trunc(v) || q.Format == resource.BinarySI) && v < maxExactFloat:
+			scaled[name] = *resource.NewQuantity(int64(math.Round(v)), q.Format)
+		case v*1000 < math.MaxInt64:
+			scaled[name] = *resource.NewMilliQuantity(int64(math.Round(v*1000)), q.Format)
+		case v < math.MaxInt64:
+			scaled[name] = *resource.NewQuantity(int64(v), q.Format)
+		default:
+			return nil, fmt.Errorf("%s scaled by %v is out of range", name, factor)
+		}
+	}
+	return scaled, nil
+}
+
+// maxExactFloat is the largest integer a float64 represents exactly.
+const maxExactFloat = 1 << 53
+
+// CPUCores returns the CPU in the resource list in cores, for example 0.5 for 500m. It
+// returns 0 if the list has no CPU.
+func (r ResourceList) CPUCores() float64 {
+	q, ok := r[ResourceCPU]
+	if !ok {
+		return 0
+	}
+	return float64(q.MilliValue()) / 1000
+}
+
+// MemoryGiB returns the memory in the resource list in GiB, for example 500 for 500Gi.
+// It returns 0 if the list has no memory.
+func (r ResourceList) MemoryGiB() float64 {
+	q, ok := r[ResourceMemory]
+	if !ok {
+		return 0
+	}
+	return q.AsApproximateFloat64() / (1 << 30)
+}
+
+// TotalGPUCapacity returns the GPU capacity of all GPU types summed up.
+func TotalGPUCapacity(r Resources) resource.Quantity {
+	total := resource.Quantity{Format: resource.DecimalSI}
+	for _, q := range r.GPUCapacity {
+		total.Add(q)
+	}
+	return total
+}
+
+// HasGPU returns true if the cluster has a positive capacity of any GPU type.
+func HasGPU(cluster Cluster) bool {
+	for _, q := range cluster.Status.Resources.GPUCapacity {
+		if q.Sign() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// NamespacesNearQuota returns the namespaces of the cluster using at least threshold,
+// a ratio such as 0.9, of the hard quota of any resource. Resources without a positive
+// hard quota are skipped.
+func NamespacesNearQuota(cluster Cluster, threshold float64) []string {
+	var namespaces []string
+	for _, q := range cluster.Status.ResourceQuotaUsage {
+		for name, used := range q.Used {
+			hard, ok := q.Hard[name]
+			if !ok || hard.Sign() <= 0 {
+				continue
+			}
+			if used.AsApproximateFloat64()/hard.AsApproximateFloat64() >= threshold {
+				namespaces = append(namespaces, q.Namespace)
+				break
+			}
+		}
+	}
+	return namespaces
+}
+
+// NormalizeResources returns a copy of the resources with canonical quantities, so that
+// collectors reporting in different units produce identical status. Memory is
+// expressed in whole bytes with the binary SI format, for example 1Gi rather than
+// 1073741824 or 1.073741824G, and CPU in milli cores with the decimal SI format, for
+// example 1500m rather than 1.5. Other resources are copied as is.
+func NormalizeResources(r Resources) Resources {
+	normalized := *r.DeepCopy()
+	normalized.Capacity = normalized.Capacity.normalize()
+	normalized.Allocatable = normalized.Allocatable.normalize()
+	normalized.Reserved = normalized.Reserved.normalize()
+	return normalized
+}
+
+func (r ResourceList) normalize() ResourceList {
+	if r == nil {
+		return nil
+	}
+	normalized := make(ResourceList, len(r))
+	for name, q := range r {
+		switch name {
+		case ResourceMemory:
+			normalized[name] = *resource.NewQuantity(q.Value(), resource.BinarySI)
+		case ResourceCPU:
+			normalized[name] = *resource.NewMilliQuantity(q.MilliValue(), resource.DecimalSI)
+		default:
+			normalized[name] = q
+		}
+	}
+	return normalized
+}
+
+// PodUtilizationRatio returns the ratio, between 0 and 1, of the pod capacity that
+// cannot be scheduled anymore. It returns 0 if the pod capacity is not reported.
+func PodUtilizationRatio(r Resources) float64 {
+	if r.PodCapacity <= 0 {
+		return 0
+	}
+	ratio := 1 - float64(r.PodAllocatable)/float64(r.PodCapacity)
+	return math.Max(0, math.Min(1, ratio))
+}
+
+// CanSchedulePods returns true if count more pods can be scheduled on the cluster.
+func CanSchedulePods(cluster Cluster, count int32) bool {
+	return count <= cluster.Status.Resources.PodAllocatable
+}
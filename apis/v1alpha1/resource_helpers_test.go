@@ -0,0 +1,479 @@
+package v1alpha1
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestUsable(t *testing.T) {
+	cases := []struct {
+		name     string
+		r        Resources
+		expected ResourceList
+	}{
+		{
+			name:     "no allocatable",
+			expected: ResourceList{},
+		},
+		{
+			name: "no reserved",
+			r: Resources{
+				Allocatable: ResourceList{ResourceCPU: resource.MustParse("4"), ResourceMemory: resource.MustParse("8Gi")},
+			},
+			expected: ResourceList{ResourceCPU: resource.MustParse("4"), ResourceMemory: resource.MustParse("8Gi")},
+		},
+		{
+			name: "reserved is subtracted",
+			r: Resources{
+				Allocatable: ResourceList{ResourceCPU: resource.MustParse("4"), ResourceMemory: resource.MustParse("8Gi")},
+				Reserved:    ResourceList{ResourceCPU: resource.MustParse("500m")},
+			},
+			expected: ResourceList{ResourceCPU: resource.MustParse("3500m"), ResourceMemory: resource.MustParse("8Gi")},
+		},
+		{
+			name: "floored at zero",
+			r: Resources{
+				Allocatable: ResourceList{ResourceCPU: resource.MustParse("1")},
+				Reserved:    ResourceList{ResourceCPU: resource.MustParse("2")},
+			},
+			expected: ResourceList{ResourceCPU: resource.MustParse("0")},
+		},
+		{
+			name: "reserved without allocatable is ignored",
+			r: Resources{
+				Allocatable: ResourceList{ResourceCPU: resource.MustParse("1")},
+				Reserved:    ResourceList{ResourceMemory: resource.MustParse("1Gi")},
+			},
+			expected: ResourceList{ResourceCPU: resource.MustParse("1")},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assertResourceList(t, c.r.Usable(), c.expected)
+		})
+	}
+}
+
+// assertResourceList checks that actual holds the same quantities as expected.
+func assertResourceList(t *testing.T, actual, expected ResourceList) {
+	t.Helper()
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	for name, q := range expected {
+		if a, ok := actual[name]; !ok || a.Cmp(q) != 0 {
+			t.Errorf("expected %s %s, got %v", name, q.String(), actual)
+		}
+	}
+}
+
+func TestRecordResourceSnapshot(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name          string
+		snapshots     int
+		max           int
+		expectedCount int
+	}{
+		{name: "below max", snapshots: 3, max: 5, expectedCount: 3},
+		{name: "at max", snapshots: 5, max: 5, expectedCount: 5},
+		{name: "above max", snapshots: 7, max: 3, expectedCount: 3},
+		{name: "zero max", snapshots: 7, expectedCount: MaxResourceSnapshots},
+		{name: "max above the limit", snapshots: 7, max: 10, expectedCount: MaxResourceSnapshots},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			status := &ClusterStatus{}
+			for i := 0; i < c.snapshots; i++ {
+				status.RecordResourceSnapshot(Resources{PodCapacity: int32(i)}, start.Add(time.Duration(i)*time.Minute), c.max)
+			}
+			if len(status.ResourcesHistory) != c.expectedCount {
+				t.Fatalf("expected %d snapshots, got %d", c.expectedCount, len(status.ResourcesHistory))
+			}
+			// The newest snapshots are kept, oldest first.
+			for i, s := range status.ResourcesHistory {
+				expected := int32(c.snapshots - c.expectedCount + i)
+				if s.Resources.PodCapacity != expected || !s.Time.Time.Equal(start.Add(time.Duration(expected)*time.Minute)) {
+					t.Errorf("expected snapshot %d at %d, got %v", i, expected, s)
+				}
+			}
+		})
+	}
+}
+
+func TestCapacityTrend(t *testing.T) {
+	snapshot := func(cpu string) ResourceSnapshot {
+		s := ResourceSnapshot{}
+		if cpu != "" {
+			s.Resources.Capacity = ResourceList{ResourceCPU: resource.MustParse(cpu)}
+		}
+		return s
+	}
+
+	cases := []struct {
+		name     string
+		history  []ResourceSnapshot
+		expected int
+	}{
+		{name: "no history"},
+		{name: "single snapshot", history: []ResourceSnapshot{snapshot("4")}},
+		{name: "increasing", history: []ResourceSnapshot{snapshot("4"), snapshot("2"), snapshot("8")}, expected: 1},
+		{name: "decreasing", history: []ResourceSnapshot{snapshot("4"), snapshot("3500m")}, expected: -1},
+		{name: "flat", history: []ResourceSnapshot{snapshot("4"), snapshot("8"), snapshot("4000m")}},
+		{name: "missing in oldest", history: []ResourceSnapshot{snapshot(""), snapshot("4")}},
+		{name: "missing in newest", history: []ResourceSnapshot{snapshot("4"), snapshot("")}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			status := &ClusterStatus{ResourcesHistory: c.history}
+			if actual := status.CapacityTrend(ResourceCPU); actual != c.expected {
+				t.Errorf("expected %d, got %d", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestTryScale(t *testing.T) {
+	r := ResourceList{
+		ResourceCPU:    resource.MustParse("3"),
+		ResourceMemory: resource.MustParse("3Gi"),
+		"pods":         resource.MustParse("110"),
+	}
+
+	cases := []struct {
+		name        string
+		r           ResourceList
+		factor      float64
+		expected    ResourceList
+		expectError bool
+	}{
+		{
+			name:     "identity",
+			r:        r,
+			factor:   1,
+			expected: r,
+		},
+		{
+			name:   "fractional factor",
+			r:      r,
+			factor: 1.5,
+			expected: ResourceList{
+				ResourceCPU:    resource.MustParse("4500m"),
+				ResourceMemory: resource.MustParse("4608Mi"),
+				"pods":         resource.MustParse("165"),
+			},
+		},
+		{
+			name:     "zero factor",
+			r:        ResourceList{ResourceCPU: resource.MustParse("3")},
+			factor:   0,
+			expected: ResourceList{ResourceCPU: resource.MustParse("0")},
+		},
+		{
+			name:     "large value too big for milli units",
+			r:        ResourceList{"storage": *resource.NewQuantity(1<<60, resource.DecimalSI)},
+			factor:   1.5,
+			expected: ResourceList{"storage": *resource.NewQuantity(3<<59, resource.DecimalSI)},
+		},
+		{
+			name:        "overflow",
+			r:           ResourceList{"storage": *resource.NewQuantity(1<<62, resource.DecimalSI)},
+			factor:      4,
+			expectError: true,
+		},
+		{
+			name:        "negative factor",
+			r:           r,
+			factor:      -1,
+			expectError: true,
+		},
+		{
+			name:        "NaN factor",
+			r:           r,
+			factor:      math.NaN(),
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			scaled, err := c.r.TryScale(c.factor)
+			if c.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", scaled)
+				}
+				if c.r.Scale(c.factor) != nil {
+					t.Errorf("expected Scale to return nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertResourceList(t, scaled, c.expected)
+			for name, q := range scaled {
+				if q.Format != c.r[name].Format {
+					t.Errorf("expected %s to keep format %s, got %s", name, c.r[name].Format, q.Format)
+				}
+			}
+		})
+	}
+}
+
+func TestCPUCoresAndMemoryGiB(t *testing.T) {
+	cases := []struct {
+		name           string
+		r              ResourceList
+		expectedCores  float64
+		expectedMemory float64
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:           "whole values",
+			r:              ResourceList{ResourceCPU: resource.MustParse("4"), ResourceMemory: resource.MustParse("500Gi")},
+			expectedCores:  4,
+			expectedMemory: 500,
+		},
+		{
+			name:           "fractional values",
+			r:              ResourceList{ResourceCPU: resource.MustParse("500m"), ResourceMemory: resource.MustParse("512Mi")},
+			expectedCores:  0.5,
+			expectedMemory: 0.5,
+		},
+		{
+			name:          "cpu only",
+			r:             ResourceList{ResourceCPU: resource.MustParse("1500m")},
+			expectedCores: 1.5,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if cores := c.r.CPUCores(); cores != c.expectedCores {
+				t.Errorf("expected %v cores, got %v", c.expectedCores, cores)
+			}
+			if memory := c.r.MemoryGiB(); memory != c.expectedMemory {
+				t.Errorf("expected %v GiB, got %v", c.expectedMemory, memory)
+			}
+		})
+	}
+}
+
+func TestGPUCapacity(t *testing.T) {
+	cases := []struct {
+		name          string
+		capacity      map[string]resource.Quantity
+		expectedTotal string
+		expectedHas   bool
+	}{
+		{
+			name:          "no gpus",
+			expectedTotal: "0",
+		},
+		{
+			name:          "single type",
+			capacity:      map[string]resource.Quantity{"A100": resource.MustParse("8")},
+			expectedTotal: "8",
+			expectedHas:   true,
+		},
+		{
+			name:          "multiple types",
+			capacity:      map[string]resource.Quantity{"A100": resource.MustParse("8"), "T4": resource.MustParse("4")},
+			expectedTotal: "12",
+			expectedHas:   true,
+		},
+		{
+			name:          "zero capacity",
+			capacity:      map[string]resource.Quantity{"A100": resource.MustParse("0")},
+			expectedTotal: "0",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.Status.Resources.GPUCapacity = c.capacity
+
+			total := TotalGPUCapacity(cluster.Status.Resources)
+			if expected := resource.MustParse(c.expectedTotal); total.Cmp(expected) != 0 {
+				t.Errorf("expected %s, got %s", expected.String(), total.String())
+			}
+			if has := HasGPU(cluster); has != c.expectedHas {
+				t.Errorf("expected %v, got %v", c.expectedHas, has)
+			}
+		})
+	}
+}
+
+func TestNamespacesNearQuota(t *testing.T) {
+	quota := func(namespace string, hard, used ResourceList) ResourceQuotaSummary {
+		return ResourceQuotaSummary{Namespace: namespace, Hard: hard, Used: used}
+	}
+	cluster := newCluster("cluster1")
+	cluster.Status.ResourceQuotaUsage = []ResourceQuotaSummary{
+		quota("below", ResourceList{ResourceCPU: resource.MustParse("10")}, ResourceList{ResourceCPU: resource.MustParse("8")}),
+		quota("at", ResourceList{ResourceCPU: resource.MustParse("10")}, ResourceList{ResourceCPU: resource.MustParse("9")}),
+		quota("over", ResourceList{ResourceMemory: resource.MustParse("1Gi")}, ResourceList{ResourceMemory: resource.MustParse("2Gi")}),
+		quota("one-resource", ResourceList{ResourceCPU: resource.MustParse("10"), ResourceMemory: resource.MustParse("10Gi")},
+			ResourceList{ResourceCPU: resource.MustParse("1"), ResourceMemory: resource.MustParse("9500Mi")}),
+		quota("no-hard", nil, ResourceList{ResourceCPU: resource.MustParse("10")}),
+		quota("zero-hard", ResourceList{ResourceCPU: resource.MustParse("0")}, ResourceList{ResourceCPU: resource.MustParse("1")}),
+	}
+
+	cases := []struct {
+		name      string
+		threshold float64
+		expected  []string
+	}{
+		{name: "ninety percent", threshold: 0.9, expected: []string{"at", "over", "one-resource"}},
+		{name: "eighty percent", threshold: 0.8, expected: []string{"below", "at", "over", "one-resource"}},
+		{name: "over quota", threshold: 1.5, expected: []string{"over"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assertStrings(t, NamespacesNearQuota(cluster, c.threshold), c.expected)
+		})
+	}
+}
+
+func TestNormalizeResources(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    ResourceList
+		expected map[ResourceName]string
+	}{
+		{
+			name:     "memory in bytes",
+			input:    ResourceList{ResourceMemory: resource.MustParse("1073741824")},
+			expected: map[ResourceName]string{ResourceMemory: "1Gi"},
+		},
+		{
+			name:     "memory in decimal SI",
+			input:    ResourceList{ResourceMemory: resource.MustParse("1.073741824G")},
+			expected: map[ResourceName]string{ResourceMemory: "1Gi"},
+		},
+		{
+			name:     "memory in smaller binary SI unit",
+			input:    ResourceList{ResourceMemory: resource.MustParse("16384Mi")},
+			expected: map[ResourceName]string{ResourceMemory: "16Gi"},
+		},
+		{
+			name:     "cpu in cores",
+			input:    ResourceList{ResourceCPU: resource.MustParse("1.5")},
+			expected: map[ResourceName]string{ResourceCPU: "1500m"},
+		},
+		{
+			name:     "cpu in milli cores",
+			input:    ResourceList{ResourceCPU: resource.MustParse("4000m")},
+			expected: map[ResourceName]string{ResourceCPU: "4"},
+		},
+		{
+			name: "other resources are unchanged",
+			input: ResourceList{
+				ResourceCPU:        resource.MustParse("0.5"),
+				"example.com/fpga": resource.MustParse("1"),
+				"nvidia.com/gpu":   resource.MustParse("2"),
+			},
+			expected: map[ResourceName]string{ResourceCPU: "500m", "example.com/fpga": "1", "nvidia.com/gpu": "2"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := Resources{Capacity: c.input, Allocatable: c.input.DeepCopy()}
+			normalized := NormalizeResources(r)
+			for _, list := range []ResourceList{normalized.Capacity, normalized.Allocatable} {
+				assertResourceList(t, list, c.input)
+				for name, expected := range c.expected {
+					if q := list[name]; q.String() != expected {
+						t.Errorf("expected %s %s, got %s", name, expected, q.String())
+					}
+				}
+			}
+			if normalized.Reserved != nil {
+				t.Errorf("expected no reserved resources, got %v", normalized.Reserved)
+			}
+		})
+	}
+
+	r := Resources{Capacity: ResourceList{ResourceMemory: resource.MustParse("1073741824")}}
+	NormalizeResources(r)
+	if q := r.Capacity[ResourceMemory]; q.String() != "1073741824" {
+		t.Errorf("expected the input to be unchanged, got %s", q.String())
+	}
+}
+
+func TestPodUtilizationRatio(t *testing.T) {
+	cases := []struct {
+		name     string
+		r        Resources
+		expected float64
+	}{
+		{
+			name: "no capacity",
+			r:    Resources{PodAllocatable: 10},
+		},
+		{
+			name:     "normal values",
+			r:        Resources{PodCapacity: 200, PodAllocatable: 50},
+			expected: 0.75,
+		},
+		{
+			name: "idle",
+			r:    Resources{PodCapacity: 110, PodAllocatable: 110},
+		},
+		{
+			name:     "full",
+			r:        Resources{PodCapacity: 110},
+			expected: 1,
+		},
+		{
+			name: "allocatable exceeds capacity",
+			r:    Resources{PodCapacity: 10, PodAllocatable: 20},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := PodUtilizationRatio(c.r); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCanSchedulePods(t *testing.T) {
+	cases := []struct {
+		name        string
+		allocatable int32
+		count       int32
+		expected    bool
+	}{
+		{name: "within allocatable", allocatable: 10, count: 5, expected: true},
+		{name: "equal to allocatable", allocatable: 10, count: 10, expected: true},
+		{name: "count exceeds allocatable", allocatable: 10, count: 11},
+		{name: "no allocatable", count: 1},
+		{name: "no pods", count: 0, expected: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.Status.Resources.PodCapacity = 110
+			cluster.Status.Resources.PodAllocatable = c.allocatable
+			if actual := CanSchedulePods(cluster, c.count); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
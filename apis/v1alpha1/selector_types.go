@@ -0,0 +1,58 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// MaxClusterSelectorScore is the score of a cluster matching a selector without any
+// untolerated soft taint.
+const MaxClusterSelectorScore = 100
+
+// ClusterSelector selects clusters by their labels and tolerates some of their taints.
+type ClusterSelector struct {
+	// LabelSelector selects clusters by their labels. Nil selects all clusters.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// Tolerations are the taints of the clusters that the selector tolerates.
+	// +optional
+	Tolerations []Toleration `json:"tolerations,omitempty"`
+}
+
+// Matches returns true if the labels of the cluster match the label selector. An invalid
+// label selector matches no cluster.
+func (s ClusterSelector) Matches(c *Cluster) bool {
+	if s.LabelSelector == nil {
+		return true
+	}
+	selector, err := metav1.LabelSelectorAsSelector(s.LabelSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(c.Labels))
+}
+
+// Score returns the score of the cluster for the selector and whether the cluster is
+// selected. A cluster is selected if it matches the label selector and has no
+// untolerated hard taint. The score of a selected cluster is MaxClusterSelectorScore
+// minus the PenaltyScore of its untolerated soft taints, and never below zero.
+func (s ClusterSelector) Score(c *Cluster) (int, bool) {
+	if !s.Matches(c) {
+		return 0, false
+	}
+	penalty, blocked := PenaltyScore(c.Spec.Taints, s.Tolerations)
+	if blocked {
+		return 0, false
+	}
+	if penalty > MaxClusterSelectorScore {
+		return 0, true
+	}
+	return MaxClusterSelectorScore - penalty, true
+}
+
+// PenaltyScore returns the penalty of the taints not tolerated by the tolerations as
+// weighted by DefaultEffectPolicy, and whether an untolerated taint has a hard effect.
+func PenaltyScore(taints []Taint, tolerations []Toleration) (int, bool) {
+	return DefaultEffectPolicy.Score(taints, tolerations)
+}
@@ -0,0 +1,87 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterSelectorScore(t *testing.T) {
+	prod := &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}
+	soft := func(key string) Taint { return Taint{Key: key, Effect: TaintEffectPreferNoSelect} }
+
+	cases := []struct {
+		name             string
+		selector         ClusterSelector
+		labels           map[string]string
+		taints           []Taint
+		expectedScore    int
+		expectedSelected bool
+	}{
+		{
+			name:             "nil label selector selects all clusters",
+			expectedScore:    MaxClusterSelectorScore,
+			expectedSelected: true,
+		},
+		{
+			name:             "matching labels",
+			selector:         ClusterSelector{LabelSelector: prod},
+			labels:           map[string]string{"env": "prod"},
+			expectedScore:    MaxClusterSelectorScore,
+			expectedSelected: true,
+		},
+		{
+			name:     "mismatching labels",
+			selector: ClusterSelector{LabelSelector: prod},
+			labels:   map[string]string{"env": "dev"},
+		},
+		{
+			name: "invalid label selector",
+			selector: ClusterSelector{LabelSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Unknown"}},
+			}},
+			labels: map[string]string{"env": "prod"},
+		},
+		{
+			name:             "untolerated soft taints lower the score",
+			taints:           []Taint{soft("a"), soft("b")},
+			expectedScore:    MaxClusterSelectorScore - 2,
+			expectedSelected: true,
+		},
+		{
+			name:             "tolerated soft taint",
+			selector:         ClusterSelector{Tolerations: []Toleration{{Key: "a", Operator: TolerationOpExists}}},
+			taints:           []Taint{soft("a"), soft("b")},
+			expectedScore:    MaxClusterSelectorScore - 1,
+			expectedSelected: true,
+		},
+		{
+			name: "score never drops below zero",
+			taints: func() []Taint {
+				var taints []Taint
+				for _, key := range repeatString("key", MaxClusterSelectorScore+1) {
+					taints = append(taints, soft(key))
+				}
+				return taints
+			}(),
+			expectedSelected: true,
+		},
+		{
+			name:   "untolerated hard taint",
+			taints: []Taint{{Key: "a", Effect: TaintEffectNoSelect}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.Labels = c.labels
+			cluster.Spec.Taints = c.taints
+
+			score, selected := c.selector.Score(&cluster)
+			if score != c.expectedScore || selected != c.expectedSelected {
+				t.Errorf("expected (%d, %v), got (%d, %v)", c.expectedScore, c.expectedSelected, score, selected)
+			}
+		})
+	}
+}
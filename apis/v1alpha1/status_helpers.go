@@ -0,0 +1,208 @@
+package v1alpha1
+
+import (
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// IsUpgrading returns true if an upgrade of the cluster is in progress.
+func IsUpgrading(cluster Cluster) bool {
+	return cluster.Status.Migration != nil && cluster.Status.Migration.Phase == MigrationPhaseInProgress
+}
+
+// UpgradeDuration returns how long the last upgrade of the cluster took. It returns
+// false if no upgrade has both started and completed.
+func UpgradeDuration(cluster Cluster) (time.Duration, bool) {
+	m := cluster.Status.Migration
+	if m == nil || m.StartedAt == nil || m.CompletedAt == nil {
+		return 0, false
+	}
+	return m.CompletedAt.Sub(m.StartedAt.Time), true
+}
+
+// IsReachableFrom returns true if the cluster is reachable from the given hub zone.
+func IsReachableFrom(cluster Cluster, zone string) bool {
+	for _, z := range cluster.Status.ReachableFrom {
+		if z == ZoneWildcard || z == zone {
+			return true
+		}
+	}
+	return false
+}
+
+// CommonReachability returns the sorted hub zones from which both clusters are
+// reachable. A wildcard on one side yields the zones of the other side, and a wildcard
+// on both sides yields the wildcard.
+func CommonReachability(a, b Cluster) []string {
+	zonesA := sets.New[string](a.Status.ReachableFrom...)
+	zonesB := sets.New[string](b.Status.ReachableFrom...)
+	switch {
+	case zonesA.Has(ZoneWildcard) && zonesB.Has(ZoneWildcard):
+		return []string{ZoneWildcard}
+	case zonesA.Has(ZoneWildcard):
+		return sets.List(zonesB)
+	case zonesB.Has(ZoneWildcard):
+		return sets.List(zonesA)
+	}
+	return sets.List(zonesA.Intersection(zonesB))
+}
+
+// SupportsKubeProxyMode returns true if the cluster reports the given kube-proxy mode.
+// It always returns false for an empty mode or KubeProxyModeUnknown.
+func SupportsKubeProxyMode(cluster Cluster, mode KubeProxyMode) bool {
+	if mode == "" || mode == KubeProxyModeUnknown {
+		return false
+	}
+	return cluster.Status.KubeProxyMode == mode
+}
+
+// HasStorageClass returns true if the storage class is available on the cluster.
+func HasStorageClass(cluster Cluster, name string) bool {
+	return sets.New[string](cluster.Status.StorageClasses...).Has(name)
+}
+
+// HasIngressClass returns true if the ingress class is available on the cluster.
+func HasIngressClass(cluster Cluster, name string) bool {
+	return sets.New[string](cluster.Status.IngressClasses...).Has(name)
+}
+
+// CommonStorageClasses returns the sorted storage classes available on both clusters.
+func CommonStorageClasses(a, b Cluster) []string {
+	return sets.List(sets.New[string](a.Status.StorageClasses...).Intersection(sets.New[string](b.Status.StorageClasses...)))
+}
+
+// HasRuntimeClass returns true if the runtime class is available on the cluster.
+func HasRuntimeClass(cluster Cluster, name string) bool {
+	return sets.New[string](cluster.Status.RuntimeClasses...).Has(name)
+}
+
+// SupportedRuntimeClasses returns the sorted runtime classes available on any of the
+// clusters.
+func SupportedRuntimeClasses(clusters []Cluster) []string {
+	classes := sets.New[string]()
+	for i := range clusters {
+		classes.Insert(clusters[i].Status.RuntimeClasses...)
+	}
+	return sets.List(classes)
+}
+
+// CommonRuntimeClasses returns the sorted runtime classes available on both clusters.
+func CommonRuntimeClasses(a, b Cluster) []string {
+	return sets.List(sets.New[string](a.Status.RuntimeClasses...).Intersection(sets.New[string](b.Status.RuntimeClasses...)))
+}
+
+// PrimaryZone returns the primary zone of the cluster, or an empty string if its zones
+// are unknown.
+func PrimaryZone(cluster Cluster) string {
+	if len(cluster.Status.Zones) == 0 {
+		return ""
+	}
+	return cluster.Status.Zones[0]
+}
+
+// IsMultiZone returns true if the cluster spans more than one zone.
+func IsMultiZone(cluster Cluster) bool {
+	return sets.New[string](cluster.Status.Zones...).Len() > 1
+}
+
+// OverlapsZones returns true if the clusters share at least one zone.
+func OverlapsZones(a, b Cluster) bool {
+	return sets.New[string](a.Status.Zones...).HasAny(b.Status.Zones...)
+}
+
+// EtcdQuorumLost returns true if etcd reports fewer than two members, which leaves no
+// member to keep quorum when one fails. It returns false if etcd status is not
+// reported.
+func EtcdQuorumLost(status ClusterStatus) bool {
+	return status.Etcd != nil && status.Etcd.MemberCount < 2
+}
+
+// NodeConditionPercentTrue returns the percentage, between 0 and 100, of nodes with the
+// given node condition True. It returns false if the summary has no counts for the
+// condition type or the counts are all zero.
+func NodeConditionPercentTrue(ns NodeSummary, condType string) (float64, bool) {
+	for _, c := range ns.NodeConditions {
+		if c.Type != condType {
+			continue
+		}
+		total := int64(c.TrueCount) + int64(c.FalseCount) + int64(c.UnknownCount)
+		if total == 0 {
+			return 0, false
+		}
+		return float64(c.TrueCount) / float64(total) * 100, true
+	}
+	return 0, false
+}
+
+// ClusterOwnsNamespace returns true if the namespace is in the namespaces of the
+// cluster. The namespaces must be sorted, see SortNamespaces.
+func ClusterOwnsNamespace(cluster Cluster, ns string) bool {
+	namespaces := cluster.Status.Namespaces
+	i := sort.SearchStrings(namespaces, ns)
+	return i < len(namespaces) && namespaces[i] == ns
+}
+
+// SortNamespaces sorts the namespaces of the cluster.
+func SortNamespaces(cluster *Cluster) {
+	sort.Strings(cluster.Status.Namespaces)
+}
+
+// MarkDisconnected records that the cluster is disconnected at now. The first call
+// records now and the API server URL as the last known good state, and every further
+// call counts a failed reconnect attempt. A nil status is ignored.
+func MarkDisconnected(status *ClusterStatus, now time.Time, apiURL string) {
+	if status == nil {
+		return
+	}
+	if status.Disconnected == nil {
+		status.Disconnected = &DisconnectedState{
+			LastKnownAPIServerURL: apiURL,
+			LastSeenAt:            metav1.NewTime(now),
+		}
+		return
+	}
+	status.Disconnected.ReconnectAttempts++
+}
+
+// ClearDisconnected records that the cluster is connected again. A nil status is
+// ignored.
+func ClearDisconnected(status *ClusterStatus) {
+	if status == nil {
+		return
+	}
+	status.Disconnected = nil
+}
+
+// SupportsAPIVersion returns true if the cluster serves the API group version, such as
+// apps/v1. The API versions must be sorted, see SetKubernetesAPIVersions.
+func SupportsAPIVersion(cluster Cluster, gv string) bool {
+	versions := cluster.Status.Version.KubernetesAPIVersions
+	i := sort.SearchStrings(versions, gv)
+	return i < len(versions) && versions[i] == gv
+}
+
+// SetKubernetesAPIVersions stores a sorted copy of the API group versions in the status.
+func SetKubernetesAPIVersions(status *ClusterStatus, versions []string) {
+	if versions == nil {
+		status.Version.KubernetesAPIVersions = nil
+		return
+	}
+	sorted := append([]string(nil), versions...)
+	sort.Strings(sorted)
+	status.Version.KubernetesAPIVersions = sorted
+}
+
+// IsAgentPresent returns true if an agent reports the status of the cluster.
+func IsAgentPresent(cluster Cluster) bool {
+	return cluster.Status.AgentStatus != nil && cluster.Status.AgentStatus.AgentID != ""
+}
+
+// NeedsExpansion returns true if the cluster has more namespaces than nsThreshold or
+// more workloads than workloadThreshold. A threshold of zero or less is ignored.
+func NeedsExpansion(cluster Cluster, nsThreshold, workloadThreshold int32) bool {
+	return (nsThreshold > 0 && cluster.Status.ManagedNamespaceCount > nsThreshold) ||
+		(workloadThreshold > 0 && cluster.Status.WorkloadCount > workloadThreshold)
+}
@@ -0,0 +1,587 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUpgradeStatus(t *testing.T) {
+	start := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(start.Add(90 * time.Minute))
+
+	cases := []struct {
+		name              string
+		migration         *MigrationStatus
+		expectedUpgrading bool
+		expectedDuration  time.Duration
+		expectedCompleted bool
+	}{
+		{
+			name: "no migration",
+		},
+		{
+			name:      "pending",
+			migration: &MigrationStatus{Phase: MigrationPhasePending},
+		},
+		{
+			name:              "in progress",
+			migration:         &MigrationStatus{Phase: MigrationPhaseInProgress, StartedAt: &start},
+			expectedUpgrading: true,
+		},
+		{
+			name:              "succeeded",
+			migration:         &MigrationStatus{Phase: MigrationPhaseSucceeded, StartedAt: &start, CompletedAt: &end},
+			expectedDuration:  90 * time.Minute,
+			expectedCompleted: true,
+		},
+		{
+			name:              "failed",
+			migration:         &MigrationStatus{Phase: MigrationPhaseFailed, StartedAt: &start, CompletedAt: &end},
+			expectedDuration:  90 * time.Minute,
+			expectedCompleted: true,
+		},
+		{
+			name:      "completed without start",
+			migration: &MigrationStatus{Phase: MigrationPhaseFailed, CompletedAt: &end},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := Cluster{Status: ClusterStatus{Migration: c.migration}}
+			if upgrading := IsUpgrading(cluster); upgrading != c.expectedUpgrading {
+				t.Errorf("expected upgrading %v, got %v", c.expectedUpgrading, upgrading)
+			}
+			duration, completed := UpgradeDuration(cluster)
+			if duration != c.expectedDuration || completed != c.expectedCompleted {
+				t.Errorf("expected (%v, %v), got (%v, %v)", c.expectedDuration, c.expectedCompleted, duration, completed)
+			}
+		})
+	}
+}
+
+func TestIsReachableFrom(t *testing.T) {
+	cases := []struct {
+		name          string
+		reachableFrom []string
+		zone          string
+		expected      bool
+	}{
+		{name: "empty list", zone: "us-east"},
+		{name: "listed zone", reachableFrom: []string{"us-east", "eu-west"}, zone: "eu-west", expected: true},
+		{name: "unlisted zone", reachableFrom: []string{"us-east"}, zone: "eu-west"},
+		{name: "wildcard", reachableFrom: []string{ZoneWildcard}, zone: "eu-west", expected: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := Cluster{Status: ClusterStatus{ReachableFrom: c.reachableFrom}}
+			if actual := IsReachableFrom(cluster, c.zone); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCommonReachability(t *testing.T) {
+	cases := []struct {
+		name     string
+		a, b     []string
+		expected []string
+	}{
+		{name: "empty lists"},
+		{name: "one empty list", a: []string{"us-east"}},
+		{name: "intersection", a: []string{"us-east", "eu-west", "ap-south"}, b: []string{"eu-west", "us-east"}, expected: []string{"eu-west", "us-east"}},
+		{name: "disjoint", a: []string{"us-east"}, b: []string{"eu-west"}},
+		{name: "wildcard on one side", a: []string{ZoneWildcard}, b: []string{"us-east", "eu-west"}, expected: []string{"eu-west", "us-east"}},
+		{name: "wildcard on both sides", a: []string{ZoneWildcard}, b: []string{ZoneWildcard, "us-east"}, expected: []string{ZoneWildcard}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a := Cluster{Status: ClusterStatus{ReachableFrom: c.a}}
+			b := Cluster{Status: ClusterStatus{ReachableFrom: c.b}}
+			assertStrings(t, CommonReachability(a, b), c.expected)
+		})
+	}
+}
+
+// assertStrings checks that actual holds the expected strings in order.
+func assertStrings(t *testing.T, actual, expected []string) {
+	t.Helper()
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, actual)
+			return
+		}
+	}
+}
+
+func TestKubeProxyModeJSON(t *testing.T) {
+	cases := []struct {
+		mode     KubeProxyMode
+		expected string
+	}{
+		{mode: KubeProxyModeIPTables, expected: `{"kubeProxyMode":"iptables"}`},
+		{mode: KubeProxyModeIPVS, expected: `{"kubeProxyMode":"ipvs"}`},
+		{mode: KubeProxyModeEBPF, expected: `{"kubeProxyMode":"ebpf"}`},
+		{mode: KubeProxyModeNone, expected: `{"kubeProxyMode":"none"}`},
+		{mode: KubeProxyModeUnknown, expected: `{"kubeProxyMode":"unknown"}`},
+	}
+
+	for _, c := range cases {
+		t.Run(string(c.mode), func(t *testing.T) {
+			status := struct {
+				KubeProxyMode KubeProxyMode `json:"kubeProxyMode,omitempty"`
+			}{KubeProxyMode: c.mode}
+			data, err := json.Marshal(status)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != c.expected {
+				t.Errorf("expected %s, got %s", c.expected, data)
+			}
+
+			var decoded ClusterStatus
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if decoded.KubeProxyMode != c.mode {
+				t.Errorf("expected %q, got %q", c.mode, decoded.KubeProxyMode)
+			}
+		})
+	}
+}
+
+func TestSupportsKubeProxyMode(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   KubeProxyMode
+		mode     KubeProxyMode
+		expected bool
+	}{
+		{name: "same mode", status: KubeProxyModeIPVS, mode: KubeProxyModeIPVS, expected: true},
+		{name: "other mode", status: KubeProxyModeIPVS, mode: KubeProxyModeEBPF},
+		{name: "unknown mode", status: KubeProxyModeUnknown, mode: KubeProxyModeUnknown},
+		{name: "empty mode", mode: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := Cluster{Status: ClusterStatus{KubeProxyMode: c.status}}
+			if actual := SupportsKubeProxyMode(cluster, c.mode); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestHasClass(t *testing.T) {
+	cases := []struct {
+		name     string
+		classes  []string
+		class    string
+		expected bool
+	}{
+		{name: "present", classes: []string{"standard", "fast"}, class: "fast", expected: true},
+		{name: "absent", classes: []string{"standard"}, class: "fast"},
+		{name: "nil slice", class: "fast"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := Cluster{Status: ClusterStatus{StorageClasses: c.classes, IngressClasses: c.classes}}
+			if actual := HasStorageClass(cluster, c.class); actual != c.expected {
+				t.Errorf("expected storage class %v, got %v", c.expected, actual)
+			}
+			if actual := HasIngressClass(cluster, c.class); actual != c.expected {
+				t.Errorf("expected ingress class %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCommonStorageClasses(t *testing.T) {
+	a := Cluster{Status: ClusterStatus{StorageClasses: []string{"standard", "fast", "local"}}}
+	b := Cluster{Status: ClusterStatus{StorageClasses: []string{"local", "standard"}}}
+
+	assertStrings(t, CommonStorageClasses(a, b), []string{"local", "standard"})
+	assertStrings(t, CommonStorageClasses(a, Cluster{}), nil)
+}
+
+func TestHasRuntimeClass(t *testing.T) {
+	cases := []struct {
+		name     string
+		classes  []string
+		class    string
+		expected bool
+	}{
+		{name: "single class", classes: []string{"gvisor"}, class: "gvisor", expected: true},
+		{name: "multiple classes", classes: []string{"runc", "kata-containers", "gvisor"}, class: "kata-containers", expected: true},
+		{name: "absent", classes: []string{"runc"}, class: "gvisor"},
+		{name: "empty", class: "gvisor"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := Cluster{Status: ClusterStatus{RuntimeClasses: c.classes}}
+			if actual := HasRuntimeClass(cluster, c.class); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSupportedAndCommonRuntimeClasses(t *testing.T) {
+	a := Cluster{Status: ClusterStatus{RuntimeClasses: []string{"runc", "gvisor"}}}
+	b := Cluster{Status: ClusterStatus{RuntimeClasses: []string{"kata-containers", "runc"}}}
+
+	assertStrings(t, SupportedRuntimeClasses([]Cluster{a, b}), []string{"gvisor", "kata-containers", "runc"})
+	assertStrings(t, SupportedRuntimeClasses([]Cluster{a, {}}), []string{"gvisor", "runc"})
+	assertStrings(t, SupportedRuntimeClasses(nil), nil)
+
+	assertStrings(t, CommonRuntimeClasses(a, b), []string{"runc"})
+	assertStrings(t, CommonRuntimeClasses(a, Cluster{}), nil)
+}
+
+func TestZones(t *testing.T) {
+	cases := []struct {
+		name              string
+		zones             []string
+		expectedPrimary   string
+		expectedMultiZone bool
+	}{
+		{name: "unknown"},
+		{name: "single zone", zones: []string{"us-east-1a"}, expectedPrimary: "us-east-1a"},
+		{name: "multi zone", zones: []string{"us-east-1b", "us-east-1a"}, expectedPrimary: "us-east-1b", expectedMultiZone: true},
+		{name: "repeated zone", zones: []string{"us-east-1a", "us-east-1a"}, expectedPrimary: "us-east-1a"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := Cluster{Status: ClusterStatus{Zones: c.zones}}
+			if actual := PrimaryZone(cluster); actual != c.expectedPrimary {
+				t.Errorf("expected primary zone %q, got %q", c.expectedPrimary, actual)
+			}
+			if actual := IsMultiZone(cluster); actual != c.expectedMultiZone {
+				t.Errorf("expected multi zone %v, got %v", c.expectedMultiZone, actual)
+			}
+		})
+	}
+}
+
+func TestOverlapsZones(t *testing.T) {
+	cases := []struct {
+		name     string
+		a, b     []string
+		expected bool
+	}{
+		{name: "unknown zones"},
+		{name: "overlap", a: []string{"us-east-1a", "us-east-1b"}, b: []string{"us-east-1b"}, expected: true},
+		{name: "disjoint", a: []string{"us-east-1a"}, b: []string{"us-east-1b"}},
+		{name: "one side unknown", a: []string{"us-east-1a"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a := Cluster{Status: ClusterStatus{Zones: c.a}}
+			b := Cluster{Status: ClusterStatus{Zones: c.b}}
+			if actual := OverlapsZones(a, b); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEtcdQuorumLost(t *testing.T) {
+	cases := []struct {
+		name     string
+		etcd     *EtcdStatus
+		expected bool
+	}{
+		{name: "not reported"},
+		{name: "1 member", etcd: &EtcdStatus{MemberCount: 1}, expected: true},
+		{name: "2 members", etcd: &EtcdStatus{MemberCount: 2}},
+		{name: "3 members", etcd: &EtcdStatus{MemberCount: 3}},
+		{name: "4 members", etcd: &EtcdStatus{MemberCount: 4}},
+		{name: "5 members", etcd: &EtcdStatus{MemberCount: 5}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := EtcdQuorumLost(ClusterStatus{Etcd: c.etcd}); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNodeConditionPercentTrue(t *testing.T) {
+	summary := NodeSummary{NodeConditions: []NodeConditionSummary{
+		{Type: "Ready", TrueCount: 3, FalseCount: 1},
+		{Type: "DiskPressure", FalseCount: 2, UnknownCount: 2},
+		{Type: "MemoryPressure"},
+	}}
+
+	cases := []struct {
+		name          string
+		condType      string
+		expected      float64
+		expectedFound bool
+	}{
+		{name: "partially true", condType: "Ready", expected: 75, expectedFound: true},
+		{name: "none true", condType: "DiskPressure", expected: 0, expectedFound: true},
+		{name: "all counts zero", condType: "MemoryPressure"},
+		{name: "missing type", condType: "PIDPressure"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			percent, found := NodeConditionPercentTrue(summary, c.condType)
+			if percent != c.expected || found != c.expectedFound {
+				t.Errorf("expected (%v, %v), got (%v, %v)", c.expected, c.expectedFound, percent, found)
+			}
+		})
+	}
+}
+
+func TestClusterOwnsNamespace(t *testing.T) {
+	cluster := newCluster("cluster1")
+	cluster.Status.Namespaces = []string{"team-b", "default", "team-a"}
+	SortNamespaces(&cluster)
+	assertStrings(t, cluster.Status.Namespaces, []string{"default", "team-a", "team-b"})
+
+	cases := []struct {
+		namespace string
+		expected  bool
+	}{
+		{namespace: "default", expected: true},
+		{namespace: "team-a", expected: true},
+		{namespace: "team-b", expected: true},
+		{namespace: "team"},
+		{namespace: "team-c"},
+		{namespace: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.namespace, func(t *testing.T) {
+			if actual := ClusterOwnsNamespace(cluster, c.namespace); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+
+	if ClusterOwnsNamespace(newCluster("empty"), "default") {
+		t.Errorf("expected a cluster without namespaces to own none")
+	}
+}
+
+func TestMarkDisconnected(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	status := &ClusterStatus{}
+
+	MarkDisconnected(status, start, "https://api.example.com")
+	expected := DisconnectedState{LastKnownAPIServerURL: "https://api.example.com", LastSeenAt: metav1.NewTime(start)}
+	if status.Disconnected == nil || *status.Disconnected != expected {
+		t.Fatalf("expected %v, got %v", expected, status.Disconnected)
+	}
+
+	// Further calls only count reconnect attempts and keep the last known state.
+	MarkDisconnected(status, start.Add(time.Minute), "https://other.example.com")
+	MarkDisconnected(status, start.Add(2*time.Minute), "")
+	expected.ReconnectAttempts = 2
+	if *status.Disconnected != expected {
+		t.Errorf("expected %v, got %v", expected, *status.Disconnected)
+	}
+
+	ClearDisconnected(status)
+	if status.Disconnected != nil {
+		t.Errorf("expected the disconnected state to be cleared, got %v", status.Disconnected)
+	}
+
+	// A nil status is ignored.
+	MarkDisconnected(nil, start, "https://api.example.com")
+	ClearDisconnected(nil)
+}
+
+func TestSupportsAPIVersion(t *testing.T) {
+	versions := []string{"v1", "apps/v1", "batch/v1", "networking.k8s.io/v1"}
+	status := &ClusterStatus{}
+	SetKubernetesAPIVersions(status, versions)
+	assertStrings(t, status.Version.KubernetesAPIVersions, []string{"apps/v1", "batch/v1", "networking.k8s.io/v1", "v1"})
+	assertStrings(t, versions, []string{"v1", "apps/v1", "batch/v1", "networking.k8s.io/v1"})
+
+	cluster := newCluster("cluster1")
+	cluster.Status = *status
+
+	cases := []struct {
+		gv       string
+		expected bool
+	}{
+		{gv: "v1", expected: true},
+		{gv: "apps/v1", expected: true},
+		{gv: "networking.k8s.io/v1", expected: true},
+		{gv: "apps/v1beta1"},
+		{gv: "batch"},
+		{gv: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.gv, func(t *testing.T) {
+			if actual := SupportsAPIVersion(cluster, c.gv); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+
+	SetKubernetesAPIVersions(status, nil)
+	if status.Version.KubernetesAPIVersions != nil {
+		t.Errorf("expected no API versions, got %v", status.Version.KubernetesAPIVersions)
+	}
+}
+
+func TestIsAgentPresent(t *testing.T) {
+	cases := []struct {
+		name        string
+		agentStatus *AgentStatus
+		expected    bool
+	}{
+		{
+			name: "no agent status",
+		},
+		{
+			name:        "no agent id",
+			agentStatus: &AgentStatus{AgentVersion: "v1.0.0", HeartbeatFailureCount: 3},
+		},
+		{
+			name:        "agent id",
+			agentStatus: &AgentStatus{AgentID: "2c1b3f4e-8a9d-4c6b-9e7f-1a2b3c4d5e6f"},
+			expected:    true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.Status.AgentStatus = c.agentStatus
+			if actual := IsAgentPresent(cluster); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAgentStatusJSON(t *testing.T) {
+	heartbeat := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	status := ClusterStatus{AgentStatus: &AgentStatus{
+		AgentID:                     "2c1b3f4e-8a9d-4c6b-9e7f-1a2b3c4d5e6f",
+		AgentPodName:                "agent-7d9f8",
+		AgentVersion:                "v1.0.0",
+		LastHeartbeatTime:           &heartbeat,
+		LastSuccessfulHeartbeatTime: &heartbeat,
+		HeartbeatFailureCount:       2,
+	}}
+
+	data, err := json.Marshal(status.AgentStatus)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"agentID":"2c1b3f4e-8a9d-4c6b-9e7f-1a2b3c4d5e6f","agentPodName":"agent-7d9f8","agentVersion":"v1.0.0",` +
+		`"lastHeartbeatTime":"2024-01-01T00:00:00Z","lastSuccessfulHeartbeatTime":"2024-01-01T00:00:00Z","heartbeatFailureCount":2}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	var decoded AgentStatus
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.AgentID != status.AgentStatus.AgentID || decoded.HeartbeatFailureCount != 2 || !decoded.LastHeartbeatTime.Equal(&heartbeat) {
+		t.Errorf("expected %v, got %v", *status.AgentStatus, decoded)
+	}
+
+	copied := status.DeepCopy()
+	copied.AgentStatus.LastHeartbeatTime.Time = time.Time{}
+	if status.AgentStatus.LastHeartbeatTime.IsZero() {
+		t.Errorf("expected the deep copy not to share the heartbeat time")
+	}
+}
+
+func TestNeedsExpansion(t *testing.T) {
+	cases := []struct {
+		name              string
+		namespaces        int32
+		workloads         int32
+		nsThreshold       int32
+		workloadThreshold int32
+		expected          bool
+	}{
+		{
+			name:              "both below threshold",
+			namespaces:        10,
+			workloads:         100,
+			nsThreshold:       20,
+			workloadThreshold: 200,
+		},
+		{
+			name:              "both at threshold",
+			namespaces:        20,
+			workloads:         200,
+			nsThreshold:       20,
+			workloadThreshold: 200,
+		},
+		{
+			name:              "namespaces over threshold",
+			namespaces:        21,
+			workloads:         100,
+			nsThreshold:       20,
+			workloadThreshold: 200,
+			expected:          true,
+		},
+		{
+			name:              "workloads over threshold",
+			namespaces:        10,
+			workloads:         201,
+			nsThreshold:       20,
+			workloadThreshold: 200,
+			expected:          true,
+		},
+		{
+			name:              "both over threshold",
+			namespaces:        21,
+			workloads:         201,
+			nsThreshold:       20,
+			workloadThreshold: 200,
+			expected:          true,
+		},
+		{
+			name:       "zero thresholds",
+			namespaces: 21,
+			workloads:  201,
+		},
+		{
+			name:              "negative thresholds",
+			namespaces:        21,
+			workloads:         201,
+			nsThreshold:       -1,
+			workloadThreshold: -1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.Status.ManagedNamespaceCount = c.namespaces
+			cluster.Status.WorkloadCount = c.workloads
+			if actual := NeedsExpansion(cluster, c.nsThreshold, c.workloadThreshold); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TaintsAnnotation encodes the taints of the spec in the compact node-style format
+// key=value:Effect, separated by commas. The value and "=" are omitted for taints
+// without a value. TimeAdded and TTLSeconds are not encoded, and values containing
+// commas do not round-trip.
+func (s ClusterSpec) TaintsAnnotation() string {
+	encoded := make([]string, 0, len(s.Taints))
+	for _, t := range s.Taints {
+		if t.Value == "" {
+			encoded = append(encoded, fmt.Sprintf("%s:%s", t.Key, t.Effect))
+			continue
+		}
+		encoded = append(encoded, fmt.Sprintf("%s=%s:%s", t.Key, t.Value, t.Effect))
+	}
+	return strings.Join(encoded, ",")
+}
+
+// ParseTaintsAnnotation parses taints encoded by TaintsAnnotation. An empty string
+// returns no taints. The TimeAdded of the returned taints is unset.
+func ParseTaintsAnnotation(s string) ([]Taint, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var taints []Taint
+	for _, encoded := range strings.Split(s, ",") {
+		i := strings.LastIndex(encoded, ":")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid taint %q: missing effect", encoded)
+		}
+		effect := TaintEffect(encoded[i+1:])
+		switch effect {
+		case TaintEffectNoSelect, TaintEffectPreferNoSelect, TaintEffectNoSelectIfNew:
+		default:
+			return nil, fmt.Errorf("invalid taint %q: unknown effect %q", encoded, effect)
+		}
+
+		key, value, _ := strings.Cut(encoded[:i], "=")
+		if key == "" {
+			return nil, fmt.Errorf("invalid taint %q: missing key", encoded)
+		}
+		taints = append(taints, Taint{Key: key, Value: value, Effect: effect})
+	}
+	return taints, nil
+}
@@ -0,0 +1,69 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTaintsAnnotation(t *testing.T) {
+	cases := []struct {
+		name     string
+		taints   []Taint
+		expected string
+	}{
+		{
+			name: "no taints",
+		},
+		{
+			name:     "taint without value",
+			taints:   []Taint{{Key: "example.com/maintenance", Effect: TaintEffectNoSelect}},
+			expected: "example.com/maintenance:NoSelect",
+		},
+		{
+			name: "multiple taints",
+			taints: []Taint{
+				{Key: "example.com/maintenance", Effect: TaintEffectNoSelect},
+				{Key: "gpu", Value: "a100", Effect: TaintEffectPreferNoSelect},
+				{Key: "zone", Value: "us:east", Effect: TaintEffectNoSelectIfNew},
+			},
+			expected: "example.com/maintenance:NoSelect,gpu=a100:PreferNoSelect,zone=us:east:NoSelectIfNew",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			encoded := ClusterSpec{Taints: c.taints}.TaintsAnnotation()
+			if encoded != c.expected {
+				t.Fatalf("expected %q, got %q", c.expected, encoded)
+			}
+
+			taints, err := ParseTaintsAnnotation(encoded)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(taints, c.taints) {
+				t.Errorf("expected the taints to round-trip to %v, got %v", c.taints, taints)
+			}
+		})
+	}
+}
+
+func TestParseTaintsAnnotationInvalid(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+	}{
+		{name: "missing effect", value: "key=value"},
+		{name: "unknown effect", value: "key=value:NoExecute"},
+		{name: "missing key", value: "=value:NoSelect"},
+		{name: "empty entry", value: "key:NoSelect,"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if taints, err := ParseTaintsAnnotation(c.value); err == nil {
+				t.Errorf("expected an error, got %v", taints)
+			}
+		})
+	}
+}
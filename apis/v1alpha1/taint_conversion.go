@@ -0,0 +1,75 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TaintEffectMapping maps cluster taint effects to node taint effects and back. The
+// reverse direction is a separate table, since several cluster taint effects may map to
+// the same node taint effect.
+// +kubebuilder:object:generate=false
+type TaintEffectMapping struct {
+	// ToCoreV1 maps cluster taint effects to node taint effects.
+	ToCoreV1 map[TaintEffect]corev1.TaintEffect
+	// FromCoreV1 maps node taint effects to cluster taint effects.
+	FromCoreV1 map[corev1.TaintEffect]TaintEffect
+}
+
+// DefaultTaintEffectMapping maps each cluster taint effect to its closest node taint
+// effect:
+//   - NoSelect maps to NoSchedule, as both keep new workloads away.
+//   - PreferNoSelect maps to PreferNoSchedule.
+//   - NoSelectIfNew maps to NoSchedule too, as both keep existing workloads.
+//
+// Node taint effects map back to NoSelect, except PreferNoSchedule which maps back to
+// PreferNoSelect, so a NoSelectIfNew taint converted to a node taint and back is a
+// NoSelect taint.
+var DefaultTaintEffectMapping = TaintEffectMapping{
+	ToCoreV1: map[TaintEffect]corev1.TaintEffect{
+		TaintEffectNoSelect:       corev1.TaintEffectNoSchedule,
+		TaintEffectPreferNoSelect: corev1.TaintEffectPreferNoSchedule,
+		TaintEffectNoSelectIfNew:  corev1.TaintEffectNoSchedule,
+	},
+	FromCoreV1: map[corev1.TaintEffect]TaintEffect{
+		corev1.TaintEffectNoSchedule:       TaintEffectNoSelect,
+		corev1.TaintEffectPreferNoSchedule: TaintEffectPreferNoSelect,
+		corev1.TaintEffectNoExecute:        TaintEffectNoSelect,
+	},
+}
+
+// ToCoreV1 converts the taint to a node taint using DefaultTaintEffectMapping.
+func (t Taint) ToCoreV1() corev1.Taint {
+	return t.ToCoreV1WithMapping(DefaultTaintEffectMapping)
+}
+
+// ToCoreV1WithMapping converts the taint to a node taint using the ToCoreV1 table of
+// the given mapping. An effect missing from the table is converted to an empty effect.
+func (t Taint) ToCoreV1WithMapping(mapping TaintEffectMapping) corev1.Taint {
+	timeAdded := t.TimeAdded
+	return corev1.Taint{
+		Key:       t.Key,
+		Value:     t.Value,
+		Effect:    mapping.ToCoreV1[t.Effect],
+		TimeAdded: &timeAdded,
+	}
+}
+
+// TaintFromCoreV1 converts a node taint to a taint using DefaultTaintEffectMapping.
+func TaintFromCoreV1(in corev1.Taint) Taint {
+	return TaintFromCoreV1WithMapping(in, DefaultTaintEffectMapping)
+}
+
+// TaintFromCoreV1WithMapping converts a node taint to a taint using the FromCoreV1 table
+// of the given mapping. An effect missing from the table is converted to an empty
+// effect.
+func TaintFromCoreV1WithMapping(in corev1.Taint, mapping TaintEffectMapping) Taint {
+	taint := Taint{
+		Key:    in.Key,
+		Value:  in.Value,
+		Effect: mapping.FromCoreV1[in.Effect],
+	}
+	if in.TimeAdded != nil {
+		taint.TimeAdded = *in.TimeAdded
+	}
+	return taint
+}
@@ -0,0 +1,87 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTaintCoreV1RoundTrip(t *testing.T) {
+	timeAdded := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	cases := []struct {
+		effect             TaintEffect
+		expectedCoreEffect corev1.TaintEffect
+		expectedRoundTrip  TaintEffect
+	}{
+		{effect: TaintEffectNoSelect, expectedCoreEffect: corev1.TaintEffectNoSchedule, expectedRoundTrip: TaintEffectNoSelect},
+		{effect: TaintEffectPreferNoSelect, expectedCoreEffect: corev1.TaintEffectPreferNoSchedule, expectedRoundTrip: TaintEffectPreferNoSelect},
+		// NoSelectIfNew shares NoSchedule with NoSelect, which it converts back to.
+		{effect: TaintEffectNoSelectIfNew, expectedCoreEffect: corev1.TaintEffectNoSchedule, expectedRoundTrip: TaintEffectNoSelect},
+	}
+
+	for _, c := range cases {
+		t.Run(string(c.effect), func(t *testing.T) {
+			taint := Taint{Key: "example.com/key", Value: "value", Effect: c.effect, TimeAdded: timeAdded}
+
+			core := taint.ToCoreV1()
+			if core.Key != taint.Key || core.Value != taint.Value || core.Effect != c.expectedCoreEffect {
+				t.Errorf("expected %s=%s:%s, got %v", taint.Key, taint.Value, c.expectedCoreEffect, core)
+			}
+			if core.TimeAdded == nil || !core.TimeAdded.Equal(&timeAdded) {
+				t.Errorf("expected time added %v, got %v", timeAdded, core.TimeAdded)
+			}
+
+			expected := taint
+			expected.Effect = c.expectedRoundTrip
+			if roundTrip := TaintFromCoreV1(core); roundTrip != expected {
+				t.Errorf("expected %v, got %v", expected, roundTrip)
+			}
+		})
+	}
+}
+
+func TestTaintCoreV1CustomMapping(t *testing.T) {
+	mapping := TaintEffectMapping{
+		ToCoreV1:   map[TaintEffect]corev1.TaintEffect{TaintEffectNoSelect: corev1.TaintEffectNoExecute},
+		FromCoreV1: map[corev1.TaintEffect]TaintEffect{corev1.TaintEffectNoExecute: TaintEffectNoSelect},
+	}
+
+	core := Taint{Key: "key", Effect: TaintEffectNoSelect}.ToCoreV1WithMapping(mapping)
+	if core.Effect != corev1.TaintEffectNoExecute {
+		t.Errorf("expected %s, got %s", corev1.TaintEffectNoExecute, core.Effect)
+	}
+	if taint := TaintFromCoreV1WithMapping(core, mapping); taint.Effect != TaintEffectNoSelect {
+		t.Errorf("expected %s, got %s", TaintEffectNoSelect, taint.Effect)
+	}
+
+	// Effects missing from the mapping convert to an empty effect.
+	if core := (Taint{Key: "key", Effect: TaintEffectPreferNoSelect}).ToCoreV1WithMapping(mapping); core.Effect != "" {
+		t.Errorf("expected an empty effect, got %s", core.Effect)
+	}
+	if taint := TaintFromCoreV1WithMapping(corev1.Taint{Key: "key", Effect: corev1.TaintEffectNoSchedule}, mapping); taint.Effect != "" {
+		t.Errorf("expected an empty effect, got %s", taint.Effect)
+	}
+}
+
+func TestTaintFromCoreV1(t *testing.T) {
+	cases := []struct {
+		coreEffect corev1.TaintEffect
+		expected   TaintEffect
+	}{
+		{coreEffect: corev1.TaintEffectNoSchedule, expected: TaintEffectNoSelect},
+		{coreEffect: corev1.TaintEffectPreferNoSchedule, expected: TaintEffectPreferNoSelect},
+		{coreEffect: corev1.TaintEffectNoExecute, expected: TaintEffectNoSelect},
+		{coreEffect: "Unknown", expected: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(string(c.coreEffect), func(t *testing.T) {
+			if taint := TaintFromCoreV1(corev1.Taint{Key: "key", Effect: c.coreEffect}); taint.Effect != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, taint.Effect)
+			}
+		})
+	}
+}
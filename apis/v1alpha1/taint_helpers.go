@@ -0,0 +1,228 @@
+package v1alpha1
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SyncTaintsFromConditions reconciles the managed taints of the spec with the
+// conditions in status. The TaintClusterNotReady taint is added with the NoSelect
+// effect when the Healthy condition is False and removed when it is True. Nothing is
+// changed while the condition is Unknown or missing, and user defined taints are left
+// untouched. An existing taint with the TaintClusterNotReady key is kept whatever its
+// effect, and the caller's taints slice is never modified in place.
+func SyncTaintsFromConditions(spec *ClusterSpec, status ClusterStatus) {
+	healthy := meta.FindStatusCondition(status.Conditions, ClusterConditionHealthy)
+	if healthy == nil {
+		return
+	}
+
+	switch healthy.Status {
+	case metav1.ConditionFalse:
+		for _, t := range spec.Taints {
+			if t.Key == TaintClusterNotReady {
+				return
+			}
+		}
+		taints := make([]Taint, 0, len(spec.Taints)+1)
+		spec.Taints = append(append(taints, spec.Taints...), Taint{
+			Key:       TaintClusterNotReady,
+			Effect:    TaintEffectNoSelect,
+			TimeAdded: healthy.LastTransitionTime,
+		})
+	case metav1.ConditionTrue:
+		var taints []Taint
+		for _, t := range spec.Taints {
+			if t.Key != TaintClusterNotReady {
+				taints = append(taints, t)
+			}
+		}
+		spec.Taints = taints
+	}
+}
+
+// MergeTolerations returns the union of the given toleration sets with duplicates
+// removed. Two tolerations are duplicates when their key, operator, value and effect
+// are equal. The result is sorted by key, operator, value and effect.
+func MergeTolerations(sets ...[]Toleration) []Toleration {
+	seen := map[Toleration]struct{}{}
+	var merged []Toleration
+	for _, set := range sets {
+		for _, t := range set {
+			if _, ok := seen[t]; ok {
+				continue
+			}
+			seen[t] = struct{}{}
+			merged = append(merged, t)
+		}
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		if a.Operator != b.Operator {
+			return a.Operator < b.Operator
+		}
+		if a.Value != b.Value {
+			return a.Value < b.Value
+		}
+		return a.Effect < b.Effect
+	})
+	return merged
+}
+
+// IsHard returns true if the effect prevents selecting the cluster, as opposed to only
+// discouraging it. NoSelect and NoSelectIfNew are hard effects.
+func (e TaintEffect) IsHard() bool {
+	return e == TaintEffectNoSelect || e == TaintEffectNoSelectIfNew
+}
+
+// AnyTaintEffect returns true if the cluster has at least one taint with the effect.
+func AnyTaintEffect(cluster Cluster, effect TaintEffect) bool {
+	for _, t := range cluster.Spec.Taints {
+		if t.Effect == effect {
+			return true
+		}
+	}
+	return false
+}
+
+// AllNoSelect returns true if the cluster has taints and all of them have a hard
+// effect, that is NoSelect or NoSelectIfNew.
+func AllNoSelect(cluster Cluster) bool {
+	if len(cluster.Spec.Taints) == 0 {
+		return false
+	}
+	for _, t := range cluster.Spec.Taints {
+		if !t.Effect.IsHard() {
+			return false
+		}
+	}
+	return true
+}
+
+// ToleratesTaint returns true if the toleration tolerates the taint. An empty key with
+// the Exists operator matches all keys, and an empty effect matches all effects.
+func (t Toleration) ToleratesTaint(taint Taint) bool {
+	if len(t.Effect) > 0 && t.Effect != taint.Effect {
+		return false
+	}
+	if len(t.Key) > 0 && t.Key != taint.Key {
+		return false
+	}
+
+	switch t.Operator {
+	case TolerationOpExists:
+		return true
+	case "", TolerationOpEqual:
+		return t.Value == taint.Value
+	default:
+		return false
+	}
+}
+
+// WithDefaultEffect returns the toleration as a set of tolerations with concrete
+// effects, tolerating the same taints. A toleration with an empty effect, which
+// tolerates all effects, is returned as one toleration per effect, NoSelect,
+// PreferNoSelect and NoSelectIfNew in that order; a toleration with an effect is
+// returned as is.
+func (t Toleration) WithDefaultEffect() []Toleration {
+	if len(t.Effect) > 0 {
+		return []Toleration{t}
+	}
+	effects := []TaintEffect{TaintEffectNoSelect, TaintEffectPreferNoSelect, TaintEffectNoSelectIfNew}
+	tolerations := make([]Toleration, 0, len(effects))
+	for _, effect := range effects {
+		t.Effect = effect
+		tolerations = append(tolerations, t)
+	}
+	return tolerations
+}
+
+// CapacityPressureTaints returns a PreferNoSelect taint for each resource of the
+// cluster whose allocatable to capacity ratio is below its threshold. The taint key is
+// CapacityPressureTaintPrefix followed by the resource name, with "/" replaced by ".",
+// and the "-pressure" suffix, for example cluster.inventory/cpu-pressure. Resources
+// without a reported capacity or allocatable are skipped.
+func CapacityPressureTaints(c *Cluster, thresholds map[ResourceName]float64, now time.Time) []Taint {
+	names := make([]ResourceName, 0, len(thresholds))
+	for name := range thresholds {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+
+	var taints []Taint
+	for _, name := range names {
+		capacity, ok := c.Status.Resources.Capacity[name]
+		if !ok || capacity.Sign() <= 0 {
+			continue
+		}
+		allocatable, ok := c.Status.Resources.Allocatable[name]
+		if !ok {
+			continue
+		}
+		if allocatable.AsApproximateFloat64()/capacity.AsApproximateFloat64() >= thresholds[name] {
+			continue
+		}
+		taints = append(taints, Taint{
+			Key:       CapacityPressureTaintKey(name),
+			Effect:    TaintEffectPreferNoSelect,
+			TimeAdded: metav1.NewTime(now),
+		})
+	}
+	return taints
+}
+
+// CapacityPressureTaintKey returns the key of the capacity pressure taint of a resource.
+func CapacityPressureTaintKey(name ResourceName) string {
+	return CapacityPressureTaintPrefix + strings.ReplaceAll(string(name), "/", ".") + "-pressure"
+}
+
+// PruneStaleTaints splits the taints into the remaining taints and the pruned taints
+// whose TTL has expired at now. Taints without a TTL or without a TimeAdded never
+// expire.
+func PruneStaleTaints(taints []Taint, now time.Time) ([]Taint, []Taint) {
+	var remaining, pruned []Taint
+	for _, t := range taints {
+		if expiry, ok := taintExpiry(t); ok && !now.Before(expiry) {
+			pruned = append(pruned, t)
+			continue
+		}
+		remaining = append(remaining, t)
+	}
+	return remaining, pruned
+}
+
+// ReconcileTaintTTLs removes the taints of the spec whose TTL has expired at now and
+// returns them along with the duration after which the next remaining taint expires.
+// The duration is zero if no remaining taint has a TTL, so it can be used as the
+// RequeueAfter of a reconcile result.
+func ReconcileTaintTTLs(spec *ClusterSpec, now time.Time) ([]Taint, time.Duration) {
+	remaining, pruned := PruneStaleTaints(spec.Taints, now)
+	if len(pruned) > 0 {
+		spec.Taints = remaining
+	}
+
+	var requeueAfter time.Duration
+	for _, t := range remaining {
+		if expiry, ok := taintExpiry(t); ok {
+			if d := expiry.Sub(now); requeueAfter == 0 || d < requeueAfter {
+				requeueAfter = d
+			}
+		}
+	}
+	return pruned, requeueAfter
+}
+
+func taintExpiry(t Taint) (time.Time, bool) {
+	if t.TTLSeconds == nil || t.TimeAdded.IsZero() {
+		return time.Time{}, false
+	}
+	return t.TimeAdded.Add(time.Duration(*t.TTLSeconds) * time.Second), true
+}
@@ -0,0 +1,344 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSyncTaintsFromConditions(t *testing.T) {
+	userTaint := Taint{Key: "example.com/user", Effect: TaintEffectNoSelect}
+	notReady := Taint{Key: TaintClusterNotReady, Effect: TaintEffectNoSelect}
+
+	cases := []struct {
+		name         string
+		taints       []Taint
+		conditions   []metav1.Condition
+		expectedKeys []string
+	}{
+		{
+			name:         "no condition",
+			taints:       []Taint{userTaint},
+			expectedKeys: []string{"example.com/user"},
+		},
+		{
+			name:         "unknown condition",
+			taints:       []Taint{userTaint, notReady},
+			conditions:   []metav1.Condition{newCondition(ClusterConditionHealthy, metav1.ConditionUnknown)},
+			expectedKeys: []string{"example.com/user", TaintClusterNotReady},
+		},
+		{
+			name:         "unhealthy adds the taint",
+			taints:       []Taint{userTaint},
+			conditions:   []metav1.Condition{newCondition(ClusterConditionHealthy, metav1.ConditionFalse)},
+			expectedKeys: []string{"example.com/user", TaintClusterNotReady},
+		},
+		{
+			name:         "unhealthy keeps an existing taint",
+			taints:       []Taint{notReady, userTaint},
+			conditions:   []metav1.Condition{newCondition(ClusterConditionHealthy, metav1.ConditionFalse)},
+			expectedKeys: []string{TaintClusterNotReady, "example.com/user"},
+		},
+		{
+			name:         "unhealthy keeps an existing taint with another effect",
+			taints:       []Taint{{Key: TaintClusterNotReady, Effect: TaintEffectPreferNoSelect}},
+			conditions:   []metav1.Condition{newCondition(ClusterConditionHealthy, metav1.ConditionFalse)},
+			expectedKeys: []string{TaintClusterNotReady},
+		},
+		{
+			name:         "healthy removes only the managed taint",
+			taints:       []Taint{userTaint, notReady},
+			conditions:   []metav1.Condition{newCondition(ClusterConditionHealthy, metav1.ConditionTrue)},
+			expectedKeys: []string{"example.com/user"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			original := append([]Taint(nil), c.taints...)
+			spec := &ClusterSpec{Taints: c.taints}
+			SyncTaintsFromConditions(spec, ClusterStatus{Conditions: c.conditions})
+
+			if len(spec.Taints) != len(c.expectedKeys) {
+				t.Fatalf("expected taints %v, got %v", c.expectedKeys, spec.Taints)
+			}
+			for i, key := range c.expectedKeys {
+				if spec.Taints[i].Key != key {
+					t.Errorf("expected taints %v, got %v", c.expectedKeys, spec.Taints)
+				}
+			}
+			for i := range original {
+				if c.taints[i] != original[i] {
+					t.Errorf("expected the caller's taints to be unchanged, got %v", c.taints)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncTaintsFromConditionsDoesNotAlias(t *testing.T) {
+	taints := make([]Taint, 1, 2)
+	taints[0] = Taint{Key: "example.com/user", Effect: TaintEffectNoSelect}
+	spec := &ClusterSpec{Taints: taints}
+
+	SyncTaintsFromConditions(spec, ClusterStatus{Conditions: []metav1.Condition{newCondition(ClusterConditionHealthy, metav1.ConditionFalse)}})
+	spec.Taints[0].Key = "changed"
+	if taints[0].Key != "example.com/user" {
+		t.Errorf("expected the caller's array not to be shared, got %v", taints)
+	}
+}
+
+func TestMergeTolerations(t *testing.T) {
+	a := Toleration{Key: "a", Operator: TolerationOpExists}
+	b := Toleration{Key: "b", Operator: TolerationOpEqual, Value: "1", Effect: TaintEffectNoSelect}
+	b2 := Toleration{Key: "b", Operator: TolerationOpEqual, Value: "2", Effect: TaintEffectNoSelect}
+	bPrefer := Toleration{Key: "b", Operator: TolerationOpEqual, Value: "1", Effect: TaintEffectPreferNoSelect}
+
+	cases := []struct {
+		name     string
+		sets     [][]Toleration
+		expected []Toleration
+	}{
+		{
+			name: "no sets",
+		},
+		{
+			name:     "single set is sorted",
+			sets:     [][]Toleration{{b, a}},
+			expected: []Toleration{a, b},
+		},
+		{
+			name:     "overlapping sets",
+			sets:     [][]Toleration{{b, a}, {a, b2}, {bPrefer, b}},
+			expected: []Toleration{a, b, bPrefer, b2},
+		},
+		{
+			name:     "duplicates within a set",
+			sets:     [][]Toleration{{a, a}},
+			expected: []Toleration{a},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			merged := MergeTolerations(c.sets...)
+			if len(merged) != len(c.expected) {
+				t.Fatalf("expected %v, got %v", c.expected, merged)
+			}
+			for i := range c.expected {
+				if merged[i] != c.expected[i] {
+					t.Errorf("expected %v, got %v", c.expected, merged)
+				}
+			}
+		})
+	}
+}
+
+func TestTaintEffectPredicates(t *testing.T) {
+	cases := []struct {
+		name              string
+		effects           []TaintEffect
+		expectedAll       bool
+		expectedAnyPrefer bool
+	}{
+		{
+			name: "no taints",
+		},
+		{
+			name:        "all NoSelect",
+			effects:     []TaintEffect{TaintEffectNoSelect, TaintEffectNoSelect},
+			expectedAll: true,
+		},
+		{
+			name:        "NoSelectIfNew is equivalent to NoSelect",
+			effects:     []TaintEffect{TaintEffectNoSelect, TaintEffectNoSelectIfNew},
+			expectedAll: true,
+		},
+		{
+			name:              "mixed",
+			effects:           []TaintEffect{TaintEffectNoSelect, TaintEffectPreferNoSelect},
+			expectedAnyPrefer: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := Cluster{}
+			for _, effect := range c.effects {
+				cluster.Spec.Taints = append(cluster.Spec.Taints, Taint{Key: "key", Effect: effect})
+			}
+			if actual := AllNoSelect(cluster); actual != c.expectedAll {
+				t.Errorf("expected AllNoSelect %v, got %v", c.expectedAll, actual)
+			}
+			if actual := AnyTaintEffect(cluster, TaintEffectPreferNoSelect); actual != c.expectedAnyPrefer {
+				t.Errorf("expected AnyTaintEffect %v, got %v", c.expectedAnyPrefer, actual)
+			}
+		})
+	}
+}
+
+func TestToleratesTaintEmptyEffect(t *testing.T) {
+	toleration := Toleration{Key: "key", Operator: TolerationOpEqual, Value: "value"}
+
+	for _, effect := range []TaintEffect{TaintEffectNoSelect, TaintEffectPreferNoSelect, TaintEffectNoSelectIfNew} {
+		t.Run(string(effect), func(t *testing.T) {
+			if !toleration.ToleratesTaint(Taint{Key: "key", Value: "value", Effect: effect}) {
+				t.Errorf("expected an empty effect to tolerate %s", effect)
+			}
+			if toleration.ToleratesTaint(Taint{Key: "key", Value: "other", Effect: effect}) {
+				t.Errorf("expected another value not to be tolerated")
+			}
+
+			concrete := Toleration{Key: "key", Operator: TolerationOpEqual, Value: "value", Effect: TaintEffectPreferNoSelect}
+			if expected := effect == TaintEffectPreferNoSelect; concrete.ToleratesTaint(Taint{Key: "key", Value: "value", Effect: effect}) != expected {
+				t.Errorf("expected a PreferNoSelect toleration to tolerate %s: %v", effect, expected)
+			}
+		})
+	}
+}
+
+func TestWithDefaultEffect(t *testing.T) {
+	allEffects := []TaintEffect{TaintEffectNoSelect, TaintEffectPreferNoSelect, TaintEffectNoSelectIfNew}
+
+	cases := []struct {
+		name     string
+		effect   TaintEffect
+		expected []TaintEffect
+	}{
+		{name: "empty effect", expected: allEffects},
+		{name: "concrete effect", effect: TaintEffectPreferNoSelect, expected: []TaintEffect{TaintEffectPreferNoSelect}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			toleration := Toleration{Key: "key", Operator: TolerationOpExists, Effect: c.effect}
+			normalized := toleration.WithDefaultEffect()
+			var effects []string
+			for _, n := range normalized {
+				effects = append(effects, string(n.Effect))
+				if n.Key != toleration.Key || n.Operator != toleration.Operator {
+					t.Errorf("expected only the effect to change, got %v from %v", n, toleration)
+				}
+			}
+			var expected []string
+			for _, effect := range c.expected {
+				expected = append(expected, string(effect))
+			}
+			assertStrings(t, effects, expected)
+			if toleration.Effect != c.effect {
+				t.Errorf("expected the toleration to be unchanged, got effect %q", toleration.Effect)
+			}
+
+			// The normalized tolerations tolerate the same taints as the toleration.
+			for _, effect := range allEffects {
+				taint := Taint{Key: "key", Effect: effect}
+				tolerated := false
+				for _, n := range normalized {
+					tolerated = tolerated || n.ToleratesTaint(taint)
+				}
+				if tolerated != toleration.ToleratesTaint(taint) {
+					t.Errorf("expected the normalized tolerations to tolerate a %s taint: %v", effect, toleration.ToleratesTaint(taint))
+				}
+			}
+		})
+	}
+}
+
+func TestCapacityPressureTaints(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	thresholds := map[ResourceName]float64{ResourceCPU: 0.2, ResourceMemory: 0.1, "nvidia.com/gpu": 0.5}
+
+	cases := []struct {
+		name         string
+		allocatable  ResourceList
+		expectedKeys []string
+	}{
+		{
+			name:        "above thresholds",
+			allocatable: ResourceList{ResourceCPU: resource.MustParse("3"), ResourceMemory: resource.MustParse("8Gi"), "nvidia.com/gpu": resource.MustParse("4")},
+		},
+		{
+			name:        "at thresholds",
+			allocatable: ResourceList{ResourceCPU: resource.MustParse("2"), ResourceMemory: resource.MustParse("1Gi"), "nvidia.com/gpu": resource.MustParse("4")},
+		},
+		{
+			name:         "just below thresholds",
+			allocatable:  ResourceList{ResourceCPU: resource.MustParse("1999m"), ResourceMemory: resource.MustParse("1023Mi"), "nvidia.com/gpu": resource.MustParse("3")},
+			expectedKeys: []string{"cluster.inventory/cpu-pressure", "cluster.inventory/memory-pressure", "cluster.inventory/nvidia.com.gpu-pressure"},
+		},
+		{
+			name:         "unreported allocatable is skipped",
+			allocatable:  ResourceList{ResourceCPU: resource.MustParse("0")},
+			expectedKeys: []string{"cluster.inventory/cpu-pressure"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := &Cluster{Status: ClusterStatus{Resources: Resources{
+				Capacity:    ResourceList{ResourceCPU: resource.MustParse("10"), ResourceMemory: resource.MustParse("10Gi"), "nvidia.com/gpu": resource.MustParse("8")},
+				Allocatable: c.allocatable,
+			}}}
+
+			taints := CapacityPressureTaints(cluster, thresholds, now)
+			if len(taints) != len(c.expectedKeys) {
+				t.Fatalf("expected taints %v, got %v", c.expectedKeys, taints)
+			}
+			for i, key := range c.expectedKeys {
+				if taints[i].Key != key || taints[i].Effect != TaintEffectPreferNoSelect || !taints[i].TimeAdded.Time.Equal(now) {
+					t.Errorf("expected a PreferNoSelect taint %s added at %v, got %v", key, now, taints[i])
+				}
+			}
+		})
+	}
+}
+
+// taintKeys returns the keys of the taints in order.
+func taintKeys(taints []Taint) []string {
+	var keys []string
+	for _, t := range taints {
+		keys = append(keys, t.Key)
+	}
+	return keys
+}
+
+func TestPruneStaleTaints(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	ttl := func(seconds int32) *int32 { return &seconds }
+	newTaint := func(key string, added time.Time, ttlSeconds *int32) Taint {
+		return Taint{Key: key, Effect: TaintEffectNoSelect, TimeAdded: metav1.NewTime(added), TTLSeconds: ttlSeconds}
+	}
+
+	taints := []Taint{
+		newTaint("permanent", now.Add(-time.Hour), nil),
+		newTaint("expired", now.Add(-time.Hour), ttl(60)),
+		newTaint("at-expiry", now.Add(-time.Minute), ttl(60)),
+		newTaint("expires-soon", now.Add(-30*time.Second), ttl(60)),
+		newTaint("expires-later", now, ttl(600)),
+		{Key: "no-time-added", Effect: TaintEffectNoSelect, TTLSeconds: ttl(1)},
+	}
+
+	remaining, pruned := PruneStaleTaints(taints, now)
+	assertStrings(t, taintKeys(remaining), []string{"permanent", "expires-soon", "expires-later", "no-time-added"})
+	assertStrings(t, taintKeys(pruned), []string{"expired", "at-expiry"})
+
+	t.Run("reconcile", func(t *testing.T) {
+		spec := &ClusterSpec{Taints: append([]Taint(nil), taints...)}
+		pruned, requeueAfter := ReconcileTaintTTLs(spec, now)
+		assertStrings(t, taintKeys(pruned), []string{"expired", "at-expiry"})
+		assertStrings(t, taintKeys(spec.Taints), []string{"permanent", "expires-soon", "expires-later", "no-time-added"})
+		if requeueAfter != 30*time.Second {
+			t.Errorf("expected to requeue after 30s, got %v", requeueAfter)
+		}
+	})
+
+	t.Run("reconcile without ttls", func(t *testing.T) {
+		spec := &ClusterSpec{Taints: []Taint{newTaint("permanent", now, nil)}}
+		pruned, requeueAfter := ReconcileTaintTTLs(spec, now)
+		if len(pruned) != 0 || requeueAfter != 0 || len(spec.Taints) != 1 {
+			t.Errorf("expected nothing to be pruned and no requeue, got %v, %v and %v", pruned, requeueAfter, spec.Taints)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseToleration parses a toleration in the command-line format key[=value][:Effect].
+// A toleration with a value uses the Equal operator and one without a value the Exists
+// operator, and an omitted effect matches all effects. For example key=value:NoSelect,
+// key:NoSelect and key.
+func ParseToleration(s string) (Toleration, error) {
+	rest, effect, hasEffect := s, "", false
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		rest, effect, hasEffect = s[:i], s[i+1:], true
+	}
+	key, value, hasValue := strings.Cut(rest, "=")
+	if key == "" {
+		return Toleration{}, fmt.Errorf("invalid toleration %q: missing key", s)
+	}
+
+	t := Toleration{Key: key, Operator: TolerationOpExists}
+	if hasValue {
+		t.Operator = TolerationOpEqual
+		t.Value = value
+	}
+	if hasEffect {
+		t.Effect = TaintEffect(effect)
+		switch t.Effect {
+		case TaintEffectNoSelect, TaintEffectPreferNoSelect, TaintEffectNoSelectIfNew:
+		default:
+			return Toleration{}, fmt.Errorf("invalid toleration %q: unknown effect %q", s, effect)
+		}
+	}
+	return t, nil
+}
+
+// String returns the toleration in the command-line format parsed by ParseToleration.
+func (t Toleration) String() string {
+	s := t.Key
+	if t.Operator != TolerationOpExists {
+		s += "=" + t.Value
+	}
+	if t.Effect != "" {
+		s += ":" + string(t.Effect)
+	}
+	return s
+}
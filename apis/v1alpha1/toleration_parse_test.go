@@ -0,0 +1,74 @@
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestParseToleration(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected Toleration
+	}{
+		{
+			value:    "key=value:NoSelect",
+			expected: Toleration{Key: "key", Operator: TolerationOpEqual, Value: "value", Effect: TaintEffectNoSelect},
+		},
+		{
+			value:    "example.com/key:PreferNoSelect",
+			expected: Toleration{Key: "example.com/key", Operator: TolerationOpExists, Effect: TaintEffectPreferNoSelect},
+		},
+		{
+			value:    "key",
+			expected: Toleration{Key: "key", Operator: TolerationOpExists},
+		},
+		{
+			value:    "key=value",
+			expected: Toleration{Key: "key", Operator: TolerationOpEqual, Value: "value"},
+		},
+		{
+			value:    "key=:NoSelectIfNew",
+			expected: Toleration{Key: "key", Operator: TolerationOpEqual, Effect: TaintEffectNoSelectIfNew},
+		},
+		{
+			value:    "zone=us:east:NoSelect",
+			expected: Toleration{Key: "zone", Operator: TolerationOpEqual, Value: "us:east", Effect: TaintEffectNoSelect},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.value, func(t *testing.T) {
+			toleration, err := ParseToleration(c.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if toleration != c.expected {
+				t.Fatalf("expected %v, got %v", c.expected, toleration)
+			}
+			if s := toleration.String(); s != c.value {
+				t.Errorf("expected the toleration to round-trip to %q, got %q", c.value, s)
+			}
+		})
+	}
+}
+
+func TestParseTolerationInvalid(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+	}{
+		{name: "empty", value: ""},
+		{name: "missing key", value: "=value:NoSelect"},
+		{name: "missing key with effect", value: ":NoSelect"},
+		{name: "unknown effect", value: "key=value:NoExecute"},
+		{name: "empty effect", value: "key:"},
+		{name: "value containing a colon without effect", value: "zone=us:east"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := ParseToleration(c.value); err == nil {
+				t.Errorf("expected an error parsing %q", c.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,467 @@
+package v1alpha1
+
+import (
+	"bytes"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	maxReachableFromZones = 64
+	maxClasses            = 64
+	maxRuntimeClasses     = 32
+	maxZones              = 32
+	maxAPIServerCertSANs  = 64
+
+	maxResourceQuotaSummaries = 256
+	maxNamespaces             = 512
+	maxAPIVersions            = 512
+
+	maxMaintenanceContacts      = 8
+	maxMaintenanceContactLength = 512
+
+	maxGracefulShutdownSeconds = 86400
+)
+
+// ValidateCluster validates a cluster and returns the list of errors found.
+func ValidateCluster(cluster *Cluster) field.ErrorList {
+	return validateCluster(cluster, nil)
+}
+
+// ValidateClusterUpdate validates an update of a cluster in addition to ValidateCluster.
+// The access refs of a joined cluster may only be appended to, unless the new cluster
+// has the AllowAccessRefUpdateAnnotation set to "true".
+func ValidateClusterUpdate(newCluster, oldCluster *Cluster) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if oldCluster.IsJoined() && newCluster.Annotations[AllowAccessRefUpdateAnnotation] != "true" {
+		allErrs = append(allErrs, ValidateAccessObjectRefImmutability(oldCluster.Spec.AccessObjectRefs,
+			newCluster.Spec.AccessObjectRefs, field.NewPath("spec", "accessObjectRef"))...)
+	}
+	return allErrs
+}
+
+// ValidateTaintEffectTransitions rejects updates weakening the effect of a taint, for
+// example from NoSelect to PreferNoSelect, unless the new cluster has the
+// AllowTaintEffectWeakeningAnnotation set to "true". Taints are identified by key, and
+// the strongest effect of a key is compared, from NoSelect over NoSelectIfNew to
+// PreferNoSelect. Removing all taints of a key is allowed. The check is not part of
+// ValidateClusterUpdate, callers opt into it.
+func ValidateTaintEffectTransitions(newCluster, oldCluster *Cluster) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if newCluster.Annotations[AllowTaintEffectWeakeningAnnotation] == "true" {
+		return allErrs
+	}
+
+	oldEffects := strongestTaintEffects(oldCluster.Spec.Taints)
+	newEffects := strongestTaintEffects(newCluster.Spec.Taints)
+	fldPath := field.NewPath("spec", "taints")
+	for i, t := range newCluster.Spec.Taints {
+		oldEffect, ok := oldEffects[t.Key]
+		if !ok || t.Effect != newEffects[t.Key] || taintEffectStrength(t.Effect) >= taintEffectStrength(oldEffect) {
+			continue
+		}
+		allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("effect"),
+			fmt.Sprintf("effect of taint %q cannot be weakened from %s to %s unless the %s annotation is \"true\"",
+				t.Key, oldEffect, t.Effect, AllowTaintEffectWeakeningAnnotation)))
+		delete(newEffects, t.Key)
+	}
+	return allErrs
+}
+
+// strongestTaintEffects returns the strongest effect of the taints of each key.
+func strongestTaintEffects(taints []Taint) map[string]TaintEffect {
+	effects := map[string]TaintEffect{}
+	for _, t := range taints {
+		if current, ok := effects[t.Key]; !ok || taintEffectStrength(t.Effect) > taintEffectStrength(current) {
+			effects[t.Key] = t.Effect
+		}
+	}
+	return effects
+}
+
+func taintEffectStrength(effect TaintEffect) int {
+	switch effect {
+	case TaintEffectNoSelect:
+		return 3
+	case TaintEffectNoSelectIfNew:
+		return 2
+	case TaintEffectPreferNoSelect:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func validateCluster(cluster *Cluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, ValidateClusterSpec(&cluster.Spec, fldPath.Child("spec"))...)
+	allErrs = append(allErrs, ValidateClusterStatus(&cluster.Status, fldPath.Child("status"))...)
+	return allErrs
+}
+
+// ValidateRequired is a shallow check of the required fields of the cluster, cheaper
+// than ValidateCluster, for early returns in controllers. It checks that the heartbeat
+// interval is positive and that each access ref has a type, a resource and either a
+// service account ref for the SERVICEACCOUNT type, or a name unless it references an
+// external secret. The first missing field is returned as a *field.Error.
+func (c *Cluster) ValidateRequired() error {
+	specPath := field.NewPath("spec")
+	if c.Spec.HealthProbe.HeartbeatIntervalSeconds <= 0 {
+		return field.Invalid(specPath.Child("healthProbe", "heatbeatIntervalSeconds"),
+			c.Spec.HealthProbe.HeartbeatIntervalSeconds, "must be positive")
+	}
+	for i, ref := range c.Spec.AccessObjectRefs {
+		refPath := specPath.Child("accessObjectRef").Index(i)
+		switch {
+		case ref.Type == "":
+			return field.Required(refPath.Child("type"), "")
+		case ref.Resource == "":
+			return field.Required(refPath.Child("resource"), "")
+		case ref.Type == AccessTypeServiceAccount && ref.ServiceAccountRef == nil:
+			return field.Required(refPath.Child("serviceAccountRef"), "")
+		case ref.Type != AccessTypeServiceAccount && ref.Name == "" && ref.ExternalSecretRef == nil:
+			return field.Required(refPath.Child("name"), "")
+		}
+	}
+	return nil
+}
+
+// ValidateYAML decodes the clusters in a YAML stream, which may contain multiple
+// documents, and validates each of them. Documents are decoded with DecodeClusters. A
+// decode error is returned as error and stops the validation, while validation errors
+// are returned in the error list with field paths prefixed by the index of the document.
+func ValidateYAML(data []byte) (field.ErrorList, error) {
+	var root *field.Path
+	allErrs := field.ErrorList{}
+	i := 0
+	err := DecodeClusters(bytes.NewReader(data), func(cluster *Cluster) error {
+		allErrs = append(allErrs, validateCluster(cluster, root.Index(i))...)
+		i++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return allErrs, nil
+}
+
+// ValidateClusterSpec validates the spec of a cluster.
+func ValidateClusterSpec(spec *ClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, ValidateAccessObjectRefs(spec.AccessObjectRefs, fldPath.Child("accessObjectRef"))...)
+	allErrs = append(allErrs, ValidateTaints(spec.Taints, fldPath.Child("taints"))...)
+	allErrs = append(allErrs, ValidateHealthProbe(spec.HealthProbe, fldPath.Child("healthProbe"))...)
+	if spec.GracefulShutdownSeconds < 0 || spec.GracefulShutdownSeconds > maxGracefulShutdownSeconds {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("gracefulShutdownSeconds"), spec.GracefulShutdownSeconds,
+			fmt.Sprintf("must be between 0 and %d", maxGracefulShutdownSeconds)))
+	}
+	allErrs = append(allErrs, ValidateMaintenanceContacts(spec.MaintenanceContacts, fldPath.Child("maintenanceContacts"))...)
+	for i, gate := range spec.ReadinessGates {
+		for _, msg := range validation.IsQualifiedName(gate) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("readinessGates").Index(i), gate, msg))
+		}
+	}
+	return allErrs
+}
+
+// ValidateClusterResourceQuota validates the resource quota of a cluster against its
+// resources. Each limit must not be negative nor exceed the allocatable amount of its
+// resource, if the cluster reports one. Pods are checked against PodAllocatable, which
+// counts as reported when PodCapacity is positive.
+func ValidateClusterResourceQuota(quota ClusterResourceQuota, resources Resources) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if quota.MaxCPU != nil {
+		allErrs = append(allErrs, validateQuotaLimit(*quota.MaxCPU, resources.Allocatable, ResourceCPU, field.NewPath("maxCPU"))...)
+	}
+	if quota.MaxMemory != nil {
+		allErrs = append(allErrs, validateQuotaLimit(*quota.MaxMemory, resources.Allocatable, ResourceMemory, field.NewPath("maxMemory"))...)
+	}
+	if quota.MaxPods != nil {
+		pods := *resource.NewQuantity(int64(*quota.MaxPods), resource.DecimalSI)
+		var allocatable ResourceList
+		if resources.PodCapacity > 0 {
+			allocatable = ResourceList{ResourcePods: *resource.NewQuantity(int64(resources.PodAllocatable), resource.DecimalSI)}
+		}
+		allErrs = append(allErrs, validateQuotaLimit(pods, allocatable, ResourcePods, field.NewPath("maxPods"))...)
+	}
+	return allErrs
+}
+
+func validateQuotaLimit(limit resource.Quantity, allocatable ResourceList, name ResourceName, fldPath *field.Path) field.ErrorList {
+	if limit.Sign() < 0 {
+		return field.ErrorList{field.Invalid(fldPath, limit.String(), "must not be negative")}
+	}
+	if a, ok := allocatable[name]; ok && limit.Cmp(a) > 0 {
+		return field.ErrorList{field.Invalid(fldPath, limit.String(),
+			fmt.Sprintf("must not exceed the allocatable %s %s", name, a.String()))}
+	}
+	return nil
+}
+
+// ValidateHealthProbe validates the health probe of a cluster. The backoff multiplier
+// and max backoff interval must be within their bounds. An HTTP probe must have an http
+// or https endpoint, and still requires the heartbeat interval, which sets the probe
+// frequency.
+func ValidateHealthProbe(hp HealthProbe, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if hp.BackoffMultiplier != "" {
+		if multiplier, err := parseMultiplier(hp.BackoffMultiplier); err != nil ||
+			multiplier < MinBackoffMultiplier || multiplier > MaxBackoffMultiplier {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("backoffMultiplier"), hp.BackoffMultiplier,
+				fmt.Sprintf("must be a decimal number between %g and %g", MinBackoffMultiplier, MaxBackoffMultiplier)))
+		}
+	}
+	if hp.MaxBackoffIntervalSeconds < 0 || hp.MaxBackoffIntervalSeconds > MaxBackoffIntervalSeconds {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxBackoffIntervalSeconds"), hp.MaxBackoffIntervalSeconds,
+			fmt.Sprintf("must be between 0 and %d", MaxBackoffIntervalSeconds)))
+	}
+	if hp.ProbeType() != HTTPProbe {
+		return allErrs
+	}
+
+	if !isHTTPURL(hp.HTTPEndpoint) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("httpEndpoint"), hp.HTTPEndpoint, "must be an http(s) URL"))
+	}
+	if hp.HeartbeatIntervalSeconds <= 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("heatbeatIntervalSeconds"),
+			"the heartbeat interval sets the frequency of the HTTP probe"))
+	}
+	if hp.HTTPExpectedStatus != 0 && (hp.HTTPExpectedStatus < 100 || hp.HTTPExpectedStatus > 599) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("httpExpectedStatus"), hp.HTTPExpectedStatus,
+			"must be between 100 and 599"))
+	}
+	return allErrs
+}
+
+// ValidateMaintenanceContacts validates the maintenance contacts of a cluster. Each
+// contact must be a bare email address or an absolute http or https URL.
+func ValidateMaintenanceContacts(contacts []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateMaxItems(len(contacts), maxMaintenanceContacts, fldPath)...)
+	for i, contact := range contacts {
+		if len(contact) > maxMaintenanceContactLength {
+			allErrs = append(allErrs, field.TooLong(fldPath.Index(i), contact, maxMaintenanceContactLength))
+			continue
+		}
+		if !isEmailAddress(contact) && !isHTTPURL(contact) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), contact, "must be an email address or an http(s) URL"))
+		}
+	}
+	return allErrs
+}
+
+func isEmailAddress(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// ValidateAccessObjectRefImmutability checks that existing access refs are neither
+// removed nor changed. Refs are matched by their type, resource and name, and by the
+// external secret or service account they reference, since those refs have no name.
+// Refs may be reordered; a matched ref must keep its namespace. New refs may be added.
+func ValidateAccessObjectRefImmutability(oldRefs, newRefs []AccessObjectRef, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	newIndexes := make(map[accessObjectRefKey]int, len(newRefs))
+	for i, ref := range newRefs {
+		newIndexes[keyOfAccessObjectRef(ref)] = i
+	}
+	for i, oldRef := range oldRefs {
+		j, ok := newIndexes[keyOfAccessObjectRef(oldRef)]
+		if !ok {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i), "access refs of a joined cluster cannot be removed"))
+			continue
+		}
+		if oldRef.Namespace != newRefs[j].Namespace {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(j), "access refs of a joined cluster cannot be changed"))
+		}
+	}
+	return allErrs
+}
+
+// accessObjectRefKey identifies an access ref.
+type accessObjectRefKey struct {
+	Type               AccessType
+	Resource           string
+	Name               string
+	ExternalSecretRef  ExternalSecretRef
+	ServiceAccountName string
+}
+
+func keyOfAccessObjectRef(ref AccessObjectRef) accessObjectRefKey {
+	key := accessObjectRefKey{Type: ref.Type, Resource: ref.Resource, Name: ref.Name}
+	if ref.ExternalSecretRef != nil {
+		key.ExternalSecretRef = *ref.ExternalSecretRef
+	}
+	if ref.ServiceAccountRef != nil {
+		key.ServiceAccountName = ref.ServiceAccountRef.ServiceAccountName
+	}
+	return key
+}
+
+// ValidateTaints validates the taints of a cluster. Taint values must only contain
+// printable characters so they render safely in logs, events and UIs.
+func ValidateTaints(taints []Taint, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, t := range taints {
+		for _, r := range t.Value {
+			if !strconv.IsPrint(r) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("value"), t.Value,
+					"must not contain newlines or non-printable characters"))
+				break
+			}
+		}
+	}
+	return allErrs
+}
+
+// ValidateToleration validates a toleration. The effect, if set, must be a known taint
+// effect. The operator must be Exists or Equal, where an empty operator means Equal; an
+// Exists toleration must not set a value and an Equal toleration must set a key.
+func ValidateToleration(t Toleration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch t.Operator {
+	case TolerationOpEqual, "":
+		if t.Key == "" {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("operator"), t.Operator,
+				"must be Exists when key is empty"))
+		}
+	case TolerationOpExists:
+		if t.Value != "" {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("value"), t.Value,
+				"must be empty when operator is Exists"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("operator"), t.Operator,
+			[]string{string(TolerationOpExists), string(TolerationOpEqual)}))
+	}
+
+	switch t.Effect {
+	case TaintEffectNoSelect, TaintEffectPreferNoSelect, TaintEffectNoSelectIfNew, "":
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("effect"), t.Effect,
+			[]string{string(TaintEffectNoSelect), string(TaintEffectPreferNoSelect), string(TaintEffectNoSelectIfNew)}))
+	}
+	return allErrs
+}
+
+// ValidateClusterStatus validates the status of a cluster.
+func ValidateClusterStatus(status *ClusterStatus, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, ValidatePropertiesUnique(status.Properties, fldPath.Child("properties"))...)
+	allErrs = append(allErrs, ValidateResources(status.Resources, fldPath.Child("resources"))...)
+	allErrs = append(allErrs, validateMaxItems(len(status.ReachableFrom), maxReachableFromZones, fldPath.Child("reachableFrom"))...)
+	allErrs = append(allErrs, validateMaxItems(len(status.StorageClasses), maxClasses, fldPath.Child("storageClasses"))...)
+	allErrs = append(allErrs, validateMaxItems(len(status.IngressClasses), maxClasses, fldPath.Child("ingressClasses"))...)
+	allErrs = append(allErrs, validateMaxItems(len(status.RuntimeClasses), maxRuntimeClasses, fldPath.Child("runtimeClasses"))...)
+	allErrs = append(allErrs, validateMaxItems(len(status.Zones), maxZones, fldPath.Child("zones"))...)
+	allErrs = append(allErrs, validateMaxItems(len(status.APIServerCertSANs), maxAPIServerCertSANs, fldPath.Child("apiServerCertSANs"))...)
+	if status.ControlPlaneEndpoint != "" && !isHTTPURL(status.ControlPlaneEndpoint) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("controlPlaneEndpoint"), status.ControlPlaneEndpoint,
+			"must be an http(s) URL"))
+	}
+	allErrs = append(allErrs, validateMaxItems(len(status.ResourceQuotaUsage), maxResourceQuotaSummaries, fldPath.Child("resourceQuotaUsage"))...)
+	allErrs = append(allErrs, validateMaxItems(len(status.Namespaces), maxNamespaces, fldPath.Child("namespaces"))...)
+	allErrs = append(allErrs, validateMaxItems(len(status.Version.KubernetesAPIVersions), maxAPIVersions,
+		fldPath.Child("version", "kubernetesAPIVersions"))...)
+	for i, zone := range status.Zones {
+		if len(zone) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("zones").Index(i), "zone must not be empty"))
+		}
+	}
+	return allErrs
+}
+
+func validateMaxItems(actual, max int, fldPath *field.Path) field.ErrorList {
+	if actual > max {
+		return field.ErrorList{field.TooMany(fldPath, actual, max)}
+	}
+	return nil
+}
+
+// ValidateResources validates the resources reported by a cluster. Pod counts must be
+// reported in PodCapacity and PodAllocatable, not as the pods resource of a resource
+// list.
+func ValidateResources(r Resources, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, ValidateResourcePairs(r, fldPath)...)
+	for _, list := range []struct {
+		name      string
+		resources ResourceList
+	}{{"capacity", r.Capacity}, {"allocatable", r.Allocatable}, {"reserved", r.Reserved}} {
+		if _, ok := list.resources[ResourcePods]; ok {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(list.name).Key(string(ResourcePods)),
+				"pod counts are reported in podCapacity and podAllocatable"))
+		}
+	}
+	for _, name := range r.Reserved.names() {
+		reserved := r.Reserved[name]
+		capacity, ok := r.Capacity[name]
+		if !ok {
+			continue
+		}
+		if reserved.Cmp(capacity) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("reserved").Key(string(name)), reserved.String(),
+				fmt.Sprintf("must be less than or equal to capacity %s", capacity.String())))
+		}
+	}
+	if r.PodCapacity < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("podCapacity"), r.PodCapacity, "must not be negative"))
+	}
+	if r.PodAllocatable < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("podAllocatable"), r.PodAllocatable, "must not be negative"))
+	} else if r.PodAllocatable > r.PodCapacity {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("podAllocatable"), r.PodAllocatable,
+			fmt.Sprintf("must be less than or equal to podCapacity %d", r.PodCapacity)))
+	}
+	return allErrs
+}
+
+// ValidatePropertiesUnique checks that no two properties share the same name. Names are
+// compared case sensitively, matching the list-map semantics of the API server, so
+// "Region" and "region" are distinct properties.
+func ValidatePropertiesUnique(props []Property, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := make(map[string]struct{}, len(props))
+	for i, p := range props {
+		if _, ok := seen[p.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), p.Name))
+			continue
+		}
+		seen[p.Name] = struct{}{}
+	}
+	return allErrs
+}
+
+// ValidateResourcePairs checks that capacity and allocatable each report either both
+// cpu and memory or neither of them, since reporting only one is most likely a
+// collection error. Other resources are not checked.
+func ValidateResourcePairs(r Resources, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateResourcePair(r.Capacity, fldPath.Child("capacity"))...)
+	allErrs = append(allErrs, validateResourcePair(r.Allocatable, fldPath.Child("allocatable"))...)
+	return allErrs
+}
+
+func validateResourcePair(rl ResourceList, fldPath *field.Path) field.ErrorList {
+	_, hasCPU := rl[ResourceCPU]
+	_, hasMemory := rl[ResourceMemory]
+	switch {
+	case hasCPU && !hasMemory:
+		return field.ErrorList{field.Required(fldPath.Key(string(ResourceMemory)), "must be reported along with cpu")}
+	case hasMemory && !hasCPU:
+		return field.ErrorList{field.Required(fldPath.Key(string(ResourceCPU)), "must be reported along with memory")}
+	}
+	return nil
+}
@@ -0,0 +1,1032 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidatePropertiesUnique(t *testing.T) {
+	cases := []struct {
+		name           string
+		props          []Property
+		expectedFields []string
+	}{
+		{
+			name: "no properties",
+		},
+		{
+			name: "no duplicates",
+			props: []Property{
+				{Name: "id.k8s.io", Value: "a"},
+				{Name: "clusterset.k8s.io", Value: "b"},
+			},
+		},
+		{
+			name: "same name",
+			props: []Property{
+				{Name: "id.k8s.io", Value: "a"},
+				{Name: "clusterset.k8s.io", Value: "b"},
+				{Name: "id.k8s.io", Value: "c"},
+			},
+			expectedFields: []string{"properties[2].name"},
+		},
+		{
+			// Names are case sensitive, like the keys of the list map.
+			name: "same name different case",
+			props: []Property{
+				{Name: "Region", Value: "a"},
+				{Name: "region", Value: "b"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := ValidatePropertiesUnique(c.props, field.NewPath("properties"))
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+// assertErrorFields checks that errs holds exactly one error for each of the fields,
+// in order.
+func assertErrorFields(t *testing.T, errs field.ErrorList, fields ...string) {
+	t.Helper()
+	if len(errs) != len(fields) {
+		t.Fatalf("expected errors for %v, got %v", fields, errs)
+	}
+	for i, f := range fields {
+		if errs[i].Field != f {
+			t.Errorf("expected error %d for field %q, got %v", i, f, errs[i])
+		}
+	}
+}
+
+func TestValidateResources(t *testing.T) {
+	cases := []struct {
+		name           string
+		r              Resources
+		expectedFields []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "reserved within capacity",
+			r: Resources{
+				Capacity: ResourceList{ResourceCPU: resource.MustParse("4"), ResourceMemory: resource.MustParse("8Gi")},
+				Reserved: ResourceList{ResourceCPU: resource.MustParse("4"), ResourceMemory: resource.MustParse("1Gi")},
+			},
+		},
+		{
+			name: "reserved exceeds capacity",
+			r: Resources{
+				Capacity: ResourceList{ResourceCPU: resource.MustParse("4"), ResourceMemory: resource.MustParse("8Gi")},
+				Reserved: ResourceList{ResourceCPU: resource.MustParse("4500m"), ResourceMemory: resource.MustParse("9Gi")},
+			},
+			expectedFields: []string{"resources.reserved[cpu]", "resources.reserved[memory]"},
+		},
+		{
+			name: "reserved without capacity",
+			r: Resources{
+				Reserved: ResourceList{ResourceCPU: resource.MustParse("1")},
+			},
+		},
+		{
+			name: "cpu without memory",
+			r: Resources{
+				Capacity: ResourceList{ResourceCPU: resource.MustParse("4")},
+			},
+			expectedFields: []string{"resources.capacity[memory]"},
+		},
+		{
+			name: "memory without cpu",
+			r: Resources{
+				Allocatable: ResourceList{ResourceMemory: resource.MustParse("8Gi")},
+			},
+			expectedFields: []string{"resources.allocatable[cpu]"},
+		},
+		{
+			name: "pods in resource lists",
+			r: Resources{
+				Capacity:    ResourceList{ResourcePods: resource.MustParse("110")},
+				Allocatable: ResourceList{ResourcePods: resource.MustParse("100")},
+				Reserved:    ResourceList{ResourcePods: resource.MustParse("10")},
+			},
+			expectedFields: []string{"resources.capacity[pods]", "resources.allocatable[pods]", "resources.reserved[pods]"},
+		},
+		{
+			name: "pod allocatable within capacity",
+			r:    Resources{PodCapacity: 110, PodAllocatable: 110},
+		},
+		{
+			name:           "pod allocatable exceeds capacity",
+			r:              Resources{PodCapacity: 10, PodAllocatable: 11},
+			expectedFields: []string{"resources.podAllocatable"},
+		},
+		{
+			name:           "negative pod counts",
+			r:              Resources{PodCapacity: -1, PodAllocatable: -1},
+			expectedFields: []string{"resources.podCapacity", "resources.podAllocatable"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := ValidateResources(c.r, field.NewPath("resources"))
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateHealthProbe(t *testing.T) {
+	cases := []struct {
+		name           string
+		hp             HealthProbe
+		expectedFields []string
+	}{
+		{
+			name: "heartbeat probe",
+			hp:   HealthProbe{HeartbeatIntervalSeconds: 60, BackoffMultiplier: "1.5", MaxBackoffIntervalSeconds: 600},
+		},
+		{
+			name: "backoff multiplier at bounds",
+			hp:   HealthProbe{HeartbeatIntervalSeconds: 60, BackoffMultiplier: "10.0"},
+		},
+		{
+			name:           "backoff multiplier too small",
+			hp:             HealthProbe{HeartbeatIntervalSeconds: 60, BackoffMultiplier: "0.99"},
+			expectedFields: []string{"healthProbe.backoffMultiplier"},
+		},
+		{
+			name:           "backoff multiplier too large",
+			hp:             HealthProbe{HeartbeatIntervalSeconds: 60, BackoffMultiplier: "10.01"},
+			expectedFields: []string{"healthProbe.backoffMultiplier"},
+		},
+		{
+			name:           "backoff multiplier not a number",
+			hp:             HealthProbe{HeartbeatIntervalSeconds: 60, BackoffMultiplier: "NaN"},
+			expectedFields: []string{"healthProbe.backoffMultiplier"},
+		},
+		{
+			name:           "max backoff interval out of range",
+			hp:             HealthProbe{HeartbeatIntervalSeconds: 60, MaxBackoffIntervalSeconds: 86401},
+			expectedFields: []string{"healthProbe.maxBackoffIntervalSeconds"},
+		},
+		{
+			name: "http probe",
+			hp:   HealthProbe{HeartbeatIntervalSeconds: 60, HTTPEndpoint: "https://cluster.example.com/healthz", HTTPExpectedStatus: 204},
+		},
+		{
+			name:           "invalid http probe",
+			hp:             HealthProbe{HTTPEndpoint: "cluster.example.com/healthz", HTTPExpectedStatus: 600},
+			expectedFields: []string{"healthProbe.httpEndpoint", "healthProbe.heatbeatIntervalSeconds", "healthProbe.httpExpectedStatus"},
+		},
+		{
+			name: "http probe status bounds",
+			hp:   HealthProbe{HeartbeatIntervalSeconds: 60, HTTPEndpoint: "http://cluster.example.com/healthz", HTTPExpectedStatus: 599},
+		},
+		{
+			name:           "http probe status too small",
+			hp:             HealthProbe{HeartbeatIntervalSeconds: 60, HTTPEndpoint: "http://cluster.example.com/healthz", HTTPExpectedStatus: 99},
+			expectedFields: []string{"healthProbe.httpExpectedStatus"},
+		},
+		{
+			name:           "http probe with another scheme",
+			hp:             HealthProbe{HeartbeatIntervalSeconds: 60, HTTPEndpoint: "tcp://cluster.example.com:6443"},
+			expectedFields: []string{"healthProbe.httpEndpoint"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := ValidateHealthProbe(c.hp, field.NewPath("healthProbe"))
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateClusterSpec(t *testing.T) {
+	cases := []struct {
+		name           string
+		mutate         func(spec *ClusterSpec)
+		expectedFields []string
+	}{
+		{
+			name:   "empty",
+			mutate: func(spec *ClusterSpec) {},
+		},
+		{
+			name: "max graceful shutdown",
+			mutate: func(spec *ClusterSpec) {
+				spec.GracefulShutdownSeconds = maxGracefulShutdownSeconds
+			},
+		},
+		{
+			name: "graceful shutdown too long",
+			mutate: func(spec *ClusterSpec) {
+				spec.GracefulShutdownSeconds = maxGracefulShutdownSeconds + 1
+			},
+			expectedFields: []string{"spec.gracefulShutdownSeconds"},
+		},
+		{
+			name: "negative graceful shutdown",
+			mutate: func(spec *ClusterSpec) {
+				spec.GracefulShutdownSeconds = -1
+			},
+			expectedFields: []string{"spec.gracefulShutdownSeconds"},
+		},
+		{
+			name: "readiness gates",
+			mutate: func(spec *ClusterSpec) {
+				spec.ReadinessGates = []string{"NetworkReady", "example.com/MonitoringReady"}
+			},
+		},
+		{
+			name: "invalid readiness gate",
+			mutate: func(spec *ClusterSpec) {
+				spec.ReadinessGates = []string{"NetworkReady", "not a qualified name"}
+			},
+			expectedFields: []string{"spec.readinessGates[1]"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			spec := &ClusterSpec{}
+			c.mutate(spec)
+			errs := ValidateClusterSpec(spec, field.NewPath("spec"))
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateMaintenanceContacts(t *testing.T) {
+	cases := []struct {
+		name           string
+		contacts       []string
+		expectedFields []string
+	}{
+		{
+			name: "no contacts",
+		},
+		{
+			name:     "email addresses and urls",
+			contacts: []string{"oncall@example.com", "https://hooks.slack.com/services/T0/B0/X", "http://pager.example.com"},
+		},
+		{
+			name:     "max contacts",
+			contacts: repeatString("oncall@example.com", maxMaintenanceContacts),
+		},
+		{
+			name:           "too many contacts",
+			contacts:       repeatString("oncall@example.com", maxMaintenanceContacts+1),
+			expectedFields: []string{"contacts"},
+		},
+		{
+			name:           "named email address",
+			contacts:       []string{"On Call <oncall@example.com>"},
+			expectedFields: []string{"contacts[0]"},
+		},
+		{
+			name:           "unsupported url scheme",
+			contacts:       []string{"oncall@example.com", "ftp://example.com"},
+			expectedFields: []string{"contacts[1]"},
+		},
+		{
+			name:           "url without host",
+			contacts:       []string{"https://"},
+			expectedFields: []string{"contacts[0]"},
+		},
+		{
+			name:           "not a contact",
+			contacts:       []string{"oncall"},
+			expectedFields: []string{"contacts[0]"},
+		},
+		{
+			name:           "too long",
+			contacts:       []string{"https://example.com/" + strings.Repeat("a", maxMaintenanceContactLength)},
+			expectedFields: []string{"contacts[0]"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := ValidateMaintenanceContacts(c.contacts, field.NewPath("contacts"))
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateClusterResourceQuota(t *testing.T) {
+	quantity := func(s string) *resource.Quantity {
+		q := resource.MustParse(s)
+		return &q
+	}
+	pods := func(n int32) *int32 { return &n }
+	resources := Resources{
+		Allocatable: ResourceList{
+			ResourceCPU:    resource.MustParse("8"),
+			ResourceMemory: resource.MustParse("32Gi"),
+		},
+		PodCapacity:    200,
+		PodAllocatable: 110,
+	}
+
+	cases := []struct {
+		name           string
+		quota          ClusterResourceQuota
+		resources      Resources
+		expectedFields []string
+	}{
+		{
+			name:      "no limits",
+			resources: resources,
+		},
+		{
+			name:      "limits at allocatable",
+			quota:     ClusterResourceQuota{MaxCPU: quantity("8"), MaxMemory: quantity("32Gi"), MaxPods: pods(110)},
+			resources: resources,
+		},
+		{
+			name:           "limits above allocatable",
+			quota:          ClusterResourceQuota{MaxCPU: quantity("8001m"), MaxMemory: quantity("33Gi"), MaxPods: pods(111)},
+			resources:      resources,
+			expectedFields: []string{"maxCPU", "maxMemory", "maxPods"},
+		},
+		{
+			name:           "negative limits",
+			quota:          ClusterResourceQuota{MaxCPU: quantity("-1"), MaxPods: pods(-1)},
+			expectedFields: []string{"maxCPU", "maxPods"},
+		},
+		{
+			name:  "unreported allocatable",
+			quota: ClusterResourceQuota{MaxCPU: quantity("1000"), MaxMemory: quantity("1Ti"), MaxPods: pods(10000)},
+		},
+		{
+			name:           "no pods allocatable",
+			quota:          ClusterResourceQuota{MaxPods: pods(1)},
+			resources:      Resources{PodCapacity: 110},
+			expectedFields: []string{"maxPods"},
+		},
+		{
+			name:  "pods in allocatable resource list are ignored",
+			quota: ClusterResourceQuota{MaxPods: pods(110)},
+			resources: Resources{
+				Allocatable:    ResourceList{ResourcePods: resource.MustParse("10")},
+				PodCapacity:    110,
+				PodAllocatable: 110,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assertErrorFields(t, ValidateClusterResourceQuota(c.quota, c.resources), c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateRequired(t *testing.T) {
+	external := &ExternalSecretRef{Provider: ExternalSecretProviderVault, Path: "cluster1"}
+
+	cases := []struct {
+		name          string
+		mutate        func(cluster *Cluster)
+		expectedField string
+	}{
+		{
+			name:   "valid",
+			mutate: func(cluster *Cluster) {},
+		},
+		{
+			name: "external secret ref without name",
+			mutate: func(cluster *Cluster) {
+				cluster.Spec.AccessObjectRefs[0].Name = ""
+				cluster.Spec.AccessObjectRefs[0].ExternalSecretRef = external
+			},
+		},
+		{
+			name: "no heartbeat interval",
+			mutate: func(cluster *Cluster) {
+				cluster.Spec.HealthProbe.HeartbeatIntervalSeconds = 0
+			},
+			expectedField: "spec.healthProbe.heatbeatIntervalSeconds",
+		},
+		{
+			name: "missing type",
+			mutate: func(cluster *Cluster) {
+				cluster.Spec.AccessObjectRefs[0].Type = ""
+			},
+			expectedField: "spec.accessObjectRef[0].type",
+		},
+		{
+			name: "missing resource",
+			mutate: func(cluster *Cluster) {
+				cluster.Spec.AccessObjectRefs[0].Resource = ""
+			},
+			expectedField: "spec.accessObjectRef[0].resource",
+		},
+		{
+			name: "missing name of the second ref",
+			mutate: func(cluster *Cluster) {
+				cluster.Spec.AccessObjectRefs = append(cluster.Spec.AccessObjectRefs,
+					AccessObjectRef{Type: AccessTypeToken, Resource: "secrets"})
+			},
+			expectedField: "spec.accessObjectRef[1].name",
+		},
+		{
+			name: "first missing field is returned",
+			mutate: func(cluster *Cluster) {
+				cluster.Spec.HealthProbe.HeartbeatIntervalSeconds = -1
+				cluster.Spec.AccessObjectRefs[0].Type = ""
+			},
+			expectedField: "spec.healthProbe.heatbeatIntervalSeconds",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster("cluster1")
+			cluster.Spec.HealthProbe.HeartbeatIntervalSeconds = 60
+			cluster.Spec.AccessObjectRefs = []AccessObjectRef{
+				{Type: AccessTypeKubeConfig, Resource: "secrets", Name: "kubeconfig", Namespace: "fleet"},
+			}
+			c.mutate(&cluster)
+
+			err := cluster.ValidateRequired()
+			if c.expectedField == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			fieldErr, ok := err.(*field.Error)
+			if !ok {
+				t.Fatalf("expected a *field.Error, got %v", err)
+			}
+			if fieldErr.Field != c.expectedField {
+				t.Errorf("expected field %s, got %s", c.expectedField, fieldErr.Field)
+			}
+		})
+	}
+}
+
+func TestValidateYAML(t *testing.T) {
+	const valid = `apiVersion: multicluster.x-k8s.io/v1alpha1
+kind: Cluster
+metadata:
+  name: cluster1
+spec:
+  healthProbe:
+    heatbeatIntervalSeconds: 60
+`
+	const invalid = `apiVersion: multicluster.x-k8s.io/v1alpha1
+kind: Cluster
+metadata:
+  name: cluster2
+spec:
+  gracefulShutdownSeconds: -1
+`
+
+	cases := []struct {
+		name           string
+		data           string
+		expectedFields []string
+		expectError    bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "single valid document",
+			data: valid,
+		},
+		{
+			name:           "multiple documents",
+			data:           valid + "---\n" + invalid + "---\n" + invalid,
+			expectedFields: []string{"[1].spec.gracefulShutdownSeconds", "[2].spec.gracefulShutdownSeconds"},
+		},
+		{
+			name: "empty documents are skipped",
+			data: "---\n" + valid + "---\n---\n" + valid,
+		},
+		{
+			name:        "malformed document",
+			data:        valid + "---\napiVersion: multicluster.x-k8s.io/v1alpha1\nkind: Cluster\nspec: [",
+			expectError: true,
+		},
+		{
+			name:        "unknown kind",
+			data:        "apiVersion: multicluster.x-k8s.io/v1alpha1\nkind: Unknown\n",
+			expectError: true,
+		},
+		{
+			name:        "not a cluster",
+			data:        "apiVersion: multicluster.x-k8s.io/v1alpha1\nkind: ClusterList\n",
+			expectError: true,
+		},
+		{
+			name:        "other api version",
+			data:        "apiVersion: v1\nkind: Cluster\n",
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs, err := ValidateYAML([]byte(c.data))
+			if c.expectError {
+				if err == nil {
+					t.Fatalf("expected a decode error, got validation errors %v", errs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateClusterStatus(t *testing.T) {
+	cases := []struct {
+		name           string
+		mutate         func(status *ClusterStatus)
+		expectedFields []string
+	}{
+		{
+			name:   "empty",
+			mutate: func(status *ClusterStatus) {},
+		},
+		{
+			name: "max reachable from zones",
+			mutate: func(status *ClusterStatus) {
+				status.ReachableFrom = repeatString("zone", maxReachableFromZones)
+			},
+		},
+		{
+			name: "too many reachable from zones",
+			mutate: func(status *ClusterStatus) {
+				status.ReachableFrom = repeatString("zone", maxReachableFromZones+1)
+			},
+			expectedFields: []string{"status.reachableFrom"},
+		},
+		{
+			name: "too many api server cert sans",
+			mutate: func(status *ClusterStatus) {
+				status.APIServerCertSANs = repeatString("api.example.com", maxAPIServerCertSANs+1)
+			},
+			expectedFields: []string{"status.apiServerCertSANs"},
+		},
+		{
+			name: "control plane endpoint",
+			mutate: func(status *ClusterStatus) {
+				status.ControlPlaneEndpoint = "https://api.example.com:6443"
+			},
+		},
+		{
+			name: "control plane endpoint without scheme",
+			mutate: func(status *ClusterStatus) {
+				status.ControlPlaneEndpoint = "api.example.com:6443"
+			},
+			expectedFields: []string{"status.controlPlaneEndpoint"},
+		},
+		{
+			name: "control plane endpoint with other scheme",
+			mutate: func(status *ClusterStatus) {
+				status.ControlPlaneEndpoint = "tcp://api.example.com:6443"
+			},
+			expectedFields: []string{"status.controlPlaneEndpoint"},
+		},
+		{
+			name: "control plane endpoint without host",
+			mutate: func(status *ClusterStatus) {
+				status.ControlPlaneEndpoint = "https://"
+			},
+			expectedFields: []string{"status.controlPlaneEndpoint"},
+		},
+		{
+			name: "too many resource quota summaries",
+			mutate: func(status *ClusterStatus) {
+				status.ResourceQuotaUsage = make([]ResourceQuotaSummary, maxResourceQuotaSummaries+1)
+			},
+			expectedFields: []string{"status.resourceQuotaUsage"},
+		},
+		{
+			name: "too many namespaces",
+			mutate: func(status *ClusterStatus) {
+				status.Namespaces = repeatString("default", maxNamespaces+1)
+			},
+			expectedFields: []string{"status.namespaces"},
+		},
+		{
+			name: "too many api versions",
+			mutate: func(status *ClusterStatus) {
+				status.Version.KubernetesAPIVersions = repeatString("v1", maxAPIVersions+1)
+			},
+			expectedFields: []string{"status.version.kubernetesAPIVersions"},
+		},
+		{
+			name: "too many storage and ingress classes",
+			mutate: func(status *ClusterStatus) {
+				status.StorageClasses = repeatString("class", maxClasses+1)
+				status.IngressClasses = repeatString("class", maxClasses+1)
+			},
+			expectedFields: []string{"status.storageClasses", "status.ingressClasses"},
+		},
+		{
+			name: "max runtime classes",
+			mutate: func(status *ClusterStatus) {
+				status.RuntimeClasses = repeatString("runtime", maxRuntimeClasses)
+			},
+		},
+		{
+			name: "too many runtime classes",
+			mutate: func(status *ClusterStatus) {
+				status.RuntimeClasses = repeatString("runtime", maxRuntimeClasses+1)
+			},
+			expectedFields: []string{"status.runtimeClasses"},
+		},
+		{
+			name: "too many zones",
+			mutate: func(status *ClusterStatus) {
+				status.Zones = repeatString("zone", maxZones+1)
+			},
+			expectedFields: []string{"status.zones"},
+		},
+		{
+			name: "empty zone",
+			mutate: func(status *ClusterStatus) {
+				status.Zones = []string{"us-east-1a", ""}
+			},
+			expectedFields: []string{"status.zones[1]"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			status := &ClusterStatus{}
+			c.mutate(status)
+			errs := ValidateClusterStatus(status, field.NewPath("status"))
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+// repeatString returns a list of n strings, all equal to s.
+func repeatString(s string, n int) []string {
+	list := make([]string, n)
+	for i := range list {
+		list[i] = s
+	}
+	return list
+}
+
+func TestValidateTaints(t *testing.T) {
+	cases := []struct {
+		name           string
+		taints         []Taint
+		expectedFields []string
+	}{
+		{
+			name: "no taints",
+		},
+		{
+			name:   "printable values",
+			taints: []Taint{{Key: "a", Value: "some value-1", Effect: TaintEffectNoSelect}, {Key: "b", Value: "ünïcode", Effect: TaintEffectNoSelect}},
+		},
+		{
+			name: "newline and control characters",
+			taints: []Taint{
+				{Key: "a", Value: "ok", Effect: TaintEffectNoSelect},
+				{Key: "b", Value: "line\nbreak", Effect: TaintEffectNoSelect},
+				{Key: "c", Value: "bell\a", Effect: TaintEffectNoSelect},
+				{Key: "d", Value: "tab\t", Effect: TaintEffectNoSelect},
+			},
+			expectedFields: []string{"taints[1].value", "taints[2].value", "taints[3].value"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := ValidateTaints(c.taints, field.NewPath("taints"))
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateClusterUpdateAccessRefs(t *testing.T) {
+	kubeconfig := AccessObjectRef{Type: AccessTypeKubeConfig, Resource: "secrets", Name: "kubeconfig", Namespace: "fleet"}
+	token := AccessObjectRef{Type: AccessTypeToken, Resource: "secrets", Name: "token", Namespace: "fleet"}
+	vault := AccessObjectRef{Type: AccessTypeKubeConfig, ExternalSecretRef: &ExternalSecretRef{Provider: ExternalSecretProviderVault, Path: "cluster1"}}
+	aws := AccessObjectRef{Type: AccessTypeKubeConfig, ExternalSecretRef: &ExternalSecretRef{Provider: ExternalSecretProviderAWS, Path: "cluster1"}}
+	serviceAccount := AccessObjectRef{Type: AccessTypeServiceAccount, ServiceAccountRef: &ServiceAccountAccessConfig{ServiceAccountName: "fleet"}}
+	joined := newCondition(ClusterConditionJoined, metav1.ConditionTrue)
+
+	cases := []struct {
+		name           string
+		oldRefs        []AccessObjectRef
+		newRefs        []AccessObjectRef
+		notJoined      bool
+		allowUpdate    bool
+		expectedFields []string
+	}{
+		{
+			name:    "unchanged",
+			oldRefs: []AccessObjectRef{kubeconfig, token},
+			newRefs: []AccessObjectRef{kubeconfig, token},
+		},
+		{
+			name:    "addition allowed",
+			oldRefs: []AccessObjectRef{kubeconfig},
+			newRefs: []AccessObjectRef{token, kubeconfig},
+		},
+		{
+			name:    "reorder allowed",
+			oldRefs: []AccessObjectRef{kubeconfig, token},
+			newRefs: []AccessObjectRef{token, kubeconfig},
+		},
+		{
+			name:           "deletion rejected",
+			oldRefs:        []AccessObjectRef{kubeconfig, token},
+			newRefs:        []AccessObjectRef{token},
+			expectedFields: []string{"spec.accessObjectRef[0]"},
+		},
+		{
+			name:    "namespace modification rejected",
+			oldRefs: []AccessObjectRef{kubeconfig, token},
+			newRefs: []AccessObjectRef{token, func() AccessObjectRef {
+				ref := kubeconfig
+				ref.Namespace = "other"
+				return ref
+			}()},
+			expectedFields: []string{"spec.accessObjectRef[1]"},
+		},
+		{
+			name:    "name modification rejected",
+			oldRefs: []AccessObjectRef{kubeconfig},
+			newRefs: []AccessObjectRef{func() AccessObjectRef {
+				ref := kubeconfig
+				ref.Name = "other"
+				return ref
+			}()},
+			expectedFields: []string{"spec.accessObjectRef[0]"},
+		},
+		{
+			name:    "external secret ref modification rejected",
+			oldRefs: []AccessObjectRef{vault},
+			newRefs: []AccessObjectRef{func() AccessObjectRef {
+				ref := vault
+				ref.ExternalSecretRef = &ExternalSecretRef{Provider: ExternalSecretProviderVault, Path: "other"}
+				return ref
+			}()},
+			expectedFields: []string{"spec.accessObjectRef[0]"},
+		},
+		{
+			name:    "reorder of refs without name allowed",
+			oldRefs: []AccessObjectRef{vault, aws, serviceAccount},
+			newRefs: []AccessObjectRef{serviceAccount, aws, vault},
+		},
+		{
+			name:           "deletion of a ref without name rejected",
+			oldRefs:        []AccessObjectRef{vault, aws},
+			newRefs:        []AccessObjectRef{vault},
+			expectedFields: []string{"spec.accessObjectRef[1]"},
+		},
+		{
+			name:    "service account modification rejected",
+			oldRefs: []AccessObjectRef{serviceAccount},
+			newRefs: []AccessObjectRef{func() AccessObjectRef {
+				ref := serviceAccount
+				ref.ServiceAccountRef = &ServiceAccountAccessConfig{ServiceAccountName: "other"}
+				return ref
+			}()},
+			expectedFields: []string{"spec.accessObjectRef[0]"},
+		},
+		{
+			name:        "annotation bypass",
+			oldRefs:     []AccessObjectRef{kubeconfig, token},
+			newRefs:     []AccessObjectRef{token},
+			allowUpdate: true,
+		},
+		{
+			name:      "not joined",
+			oldRefs:   []AccessObjectRef{kubeconfig, token},
+			newRefs:   []AccessObjectRef{token},
+			notJoined: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			oldCluster := newCluster("cluster1", joined)
+			if c.notJoined {
+				oldCluster = newCluster("cluster1")
+			}
+			oldCluster.Spec.AccessObjectRefs = c.oldRefs
+			newCluster := oldCluster.DeepCopy()
+			newCluster.Spec.AccessObjectRefs = c.newRefs
+			if c.allowUpdate {
+				newCluster.Annotations = map[string]string{AllowAccessRefUpdateAnnotation: "true"}
+			}
+
+			errs := ValidateClusterUpdate(newCluster, &oldCluster)
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateTaintEffectTransitions(t *testing.T) {
+	taint := func(key string, effect TaintEffect) Taint {
+		return Taint{Key: key, Effect: effect}
+	}
+
+	cases := []struct {
+		name           string
+		oldTaints      []Taint
+		newTaints      []Taint
+		allowWeakening bool
+		expectedFields []string
+	}{
+		{
+			name:      "unchanged",
+			oldTaints: []Taint{taint("a", TaintEffectNoSelect)},
+			newTaints: []Taint{taint("a", TaintEffectNoSelect)},
+		},
+		{
+			name:      "strengthening allowed",
+			oldTaints: []Taint{taint("a", TaintEffectPreferNoSelect), taint("b", TaintEffectNoSelectIfNew)},
+			newTaints: []Taint{taint("a", TaintEffectNoSelectIfNew), taint("b", TaintEffectNoSelect)},
+		},
+		{
+			name:           "weakening from NoSelect to PreferNoSelect rejected",
+			oldTaints:      []Taint{taint("a", TaintEffectNoSelect)},
+			newTaints:      []Taint{taint("a", TaintEffectPreferNoSelect)},
+			expectedFields: []string{"spec.taints[0].effect"},
+		},
+		{
+			name:           "weakening from NoSelect to NoSelectIfNew rejected",
+			oldTaints:      []Taint{taint("b", TaintEffectPreferNoSelect), taint("a", TaintEffectNoSelect)},
+			newTaints:      []Taint{taint("b", TaintEffectPreferNoSelect), taint("a", TaintEffectNoSelectIfNew)},
+			expectedFields: []string{"spec.taints[1].effect"},
+		},
+		{
+			name:      "weaker taint added next to a stronger one allowed",
+			oldTaints: []Taint{taint("a", TaintEffectNoSelect)},
+			newTaints: []Taint{taint("a", TaintEffectNoSelect), taint("a", TaintEffectPreferNoSelect)},
+		},
+		{
+			name:           "weakening one of several taints of a key reported once",
+			oldTaints:      []Taint{taint("a", TaintEffectNoSelect), taint("a", TaintEffectPreferNoSelect)},
+			newTaints:      []Taint{taint("a", TaintEffectPreferNoSelect), taint("a", TaintEffectPreferNoSelect)},
+			expectedFields: []string{"spec.taints[0].effect"},
+		},
+		{
+			name:      "removal allowed",
+			oldTaints: []Taint{taint("a", TaintEffectNoSelect)},
+		},
+		{
+			name:      "new key allowed",
+			newTaints: []Taint{taint("a", TaintEffectPreferNoSelect)},
+		},
+		{
+			name:           "annotation bypass",
+			oldTaints:      []Taint{taint("a", TaintEffectNoSelect)},
+			newTaints:      []Taint{taint("a", TaintEffectPreferNoSelect)},
+			allowWeakening: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			oldCluster := newCluster("cluster1")
+			oldCluster.Spec.Taints = c.oldTaints
+			newCluster := oldCluster.DeepCopy()
+			newCluster.Spec.Taints = c.newTaints
+			if c.allowWeakening {
+				newCluster.Annotations = map[string]string{AllowTaintEffectWeakeningAnnotation: "true"}
+			}
+
+			errs := ValidateTaintEffectTransitions(newCluster, &oldCluster)
+			assertErrorFields(t, errs, c.expectedFields...)
+			for _, err := range errs {
+				if !strings.Contains(err.Detail, "NoSelect to") {
+					t.Errorf("expected the error to cite the old and new effects, got %q", err.Detail)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateToleration(t *testing.T) {
+	cases := []struct {
+		name           string
+		toleration     Toleration
+		expectedFields []string
+	}{
+		{
+			name:       "equal",
+			toleration: Toleration{Key: "key", Operator: TolerationOpEqual, Value: "value", Effect: TaintEffectNoSelect},
+		},
+		{
+			name:       "empty operator means equal",
+			toleration: Toleration{Key: "key", Value: "value"},
+		},
+		{
+			name:       "exists without key tolerates everything",
+			toleration: Toleration{Operator: TolerationOpExists},
+		},
+		{
+			name:       "exists with every effect",
+			toleration: Toleration{Key: "key", Operator: TolerationOpExists, Effect: TaintEffectNoSelectIfNew},
+		},
+		{
+			name:           "equal without key",
+			toleration:     Toleration{Operator: TolerationOpEqual, Value: "value"},
+			expectedFields: []string{"operator"},
+		},
+		{
+			name:           "exists with value",
+			toleration:     Toleration{Key: "key", Operator: TolerationOpExists, Value: "value"},
+			expectedFields: []string{"value"},
+		},
+		{
+			name:           "unsupported operator",
+			toleration:     Toleration{Key: "key", Operator: "In"},
+			expectedFields: []string{"operator"},
+		},
+		{
+			name:           "unsupported effect",
+			toleration:     Toleration{Key: "key", Operator: TolerationOpExists, Effect: "NoExecute"},
+			expectedFields: []string{"effect"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assertErrorFields(t, ValidateToleration(c.toleration), c.expectedFields...)
+		})
+	}
+}
+
+func TestValidateResourcePairs(t *testing.T) {
+	cpu := resource.MustParse("4")
+	memory := resource.MustParse("8Gi")
+
+	cases := []struct {
+		name           string
+		r              Resources
+		expectedFields []string
+	}{
+		{
+			name: "neither present",
+		},
+		{
+			name: "both present",
+			r: Resources{
+				Capacity:    ResourceList{ResourceCPU: cpu, ResourceMemory: memory},
+				Allocatable: ResourceList{ResourceCPU: cpu, ResourceMemory: memory},
+			},
+		},
+		{
+			name: "additional resources alongside both",
+			r: Resources{
+				Capacity: ResourceList{ResourceCPU: cpu, ResourceMemory: memory, "nvidia.com/gpu": resource.MustParse("2")},
+			},
+		},
+		{
+			name: "additional resources only",
+			r: Resources{
+				Capacity: ResourceList{"nvidia.com/gpu": resource.MustParse("2")},
+			},
+		},
+		{
+			name: "only cpu",
+			r: Resources{
+				Capacity:    ResourceList{ResourceCPU: cpu},
+				Allocatable: ResourceList{ResourceCPU: cpu},
+			},
+			expectedFields: []string{"resources.capacity[memory]", "resources.allocatable[memory]"},
+		},
+		{
+			name: "only memory",
+			r: Resources{
+				Allocatable: ResourceList{ResourceMemory: memory},
+			},
+			expectedFields: []string{"resources.allocatable[cpu]"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := ValidateResourcePairs(c.r, field.NewPath("resources"))
+			assertErrorFields(t, errs, c.expectedFields...)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package accessutil
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// KubeConfigCertExpiry returns the expiry time of the embedded client certificate of a
+// user in the kubeconfig. The user is the one named key, or the user of the current
+// context if key is empty. It returns false if the user authenticates without an
+// embedded client certificate, for example with a token.
+func KubeConfigCertExpiry(data []byte, key string) (time.Time, bool, error) {
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	if key == "" {
+		kubeContext, ok := config.Contexts[config.CurrentContext]
+		if !ok {
+			return time.Time{}, false, fmt.Errorf("current context %q not found in kubeconfig", config.CurrentContext)
+		}
+		key = kubeContext.AuthInfo
+	}
+	authInfo, ok := config.AuthInfos[key]
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("user %q not found in kubeconfig", key)
+	}
+	if len(authInfo.ClientCertificateData) == 0 {
+		return time.Time{}, false, nil
+	}
+
+	block, _ := pem.Decode(authInfo.ClientCertificateData)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return time.Time{}, false, fmt.Errorf("client certificate of user %q is not a PEM encoded certificate", key)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid client certificate of user %q: %w", key, err)
+	}
+	return cert.NotAfter, true, nil
+}
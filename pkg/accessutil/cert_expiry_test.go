@@ -0,0 +1,112 @@
+package accessutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// newClientCert returns a PEM encoded self-signed client certificate expiring at
+// notAfter.
+func newClientCert(t *testing.T, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "user"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestKubeConfigCertExpiry(t *testing.T) {
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	withCert := newKubeConfig(t, "https://api.example.com", newClientCert(t, notAfter))
+
+	// A kubeconfig with a second user, authenticating with another certificate.
+	config, err := clientcmd.Load(withCert)
+	if err != nil {
+		t.Fatalf("failed to load kubeconfig: %v", err)
+	}
+	otherNotAfter := notAfter.Add(24 * time.Hour)
+	config.AuthInfos["other"] = &clientcmdapi.AuthInfo{ClientCertificateData: newClientCert(t, otherNotAfter)}
+	config.AuthInfos["invalid"] = &clientcmdapi.AuthInfo{ClientCertificateData: []byte("not a certificate")}
+	multiUser, err := clientcmd.Write(*config)
+	if err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	cases := []struct {
+		name          string
+		data          []byte
+		key           string
+		expected      time.Time
+		expectedFound bool
+		expectError   bool
+	}{
+		{
+			name:          "current user",
+			data:          withCert,
+			expected:      notAfter,
+			expectedFound: true,
+		},
+		{
+			name:          "named user",
+			data:          multiUser,
+			key:           "other",
+			expected:      otherNotAfter,
+			expectedFound: true,
+		},
+		{
+			name: "token user",
+			data: newKubeConfig(t, "https://api.example.com", nil),
+		},
+		{
+			name:        "missing user",
+			data:        multiUser,
+			key:         "missing",
+			expectError: true,
+		},
+		{
+			name:        "invalid certificate",
+			data:        multiUser,
+			key:         "invalid",
+			expectError: true,
+		},
+		{
+			name:        "invalid kubeconfig",
+			data:        []byte("{"),
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expiry, found, err := KubeConfigCertExpiry(c.data, c.key)
+			if c.expectError != (err != nil) {
+				t.Fatalf("expected error %v, got %v", c.expectError, err)
+			}
+			if !expiry.Equal(c.expected) || found != c.expectedFound {
+				t.Errorf("expected (%v, %v), got (%v, %v)", c.expected, c.expectedFound, expiry, found)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Package accessutil builds clients for clusters from the access refs of the cluster
+// inventory API.
+package accessutil
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+// CredentialProvider builds the REST config to access a cluster from an access ref.
+// Implementations read the objects referenced by the access ref with the given client.
+type CredentialProvider interface {
+	RESTConfig(ctx context.Context, c client.Client, ref v1alpha1.AccessObjectRef) (*rest.Config, error)
+}
+
+var (
+	providersLock sync.RWMutex
+	providers     = map[v1alpha1.AccessType]CredentialProvider{
+		v1alpha1.AccessTypeKubeConfig: &kubeConfigProvider{},
+	}
+)
+
+// RegisterCredentialProvider registers the credential provider of an access type,
+// replacing the provider previously registered for the type, including the built-in
+// ones. It is safe to call concurrently with BuildRESTConfig.
+func RegisterCredentialProvider(accessType v1alpha1.AccessType, p CredentialProvider) {
+	providersLock.Lock()
+	defer providersLock.Unlock()
+	providers[accessType] = p
+}
+
+// BuildRESTConfig builds the REST config to access a cluster with the credential
+// provider registered for the type of the access ref. A provider for the KUBECONFIG
+// type is registered by default.
+func BuildRESTConfig(ctx context.Context, c client.Client, ref v1alpha1.AccessObjectRef) (*rest.Config, error) {
+	providersLock.RLock()
+	p, ok := providers[ref.Type]
+	providersLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no credential provider registered for access type %q", ref.Type)
+	}
+	return p.RESTConfig(ctx, c, ref)
+}
+
+// Endpoint returns the control plane endpoint of the cluster. It returns the endpoint
+// reported in the status of the cluster if any, and otherwise the host of the REST
+// config built from the first access ref of the cluster that yields one.
+func Endpoint(ctx context.Context, c client.Client, cluster *v1alpha1.Cluster) (string, error) {
+	if endpoint := cluster.Endpoint(); endpoint != "" {
+		return endpoint, nil
+	}
+
+	var lastErr error
+	for _, ref := range cluster.Spec.AccessObjectRefs {
+		config, err := BuildRESTConfig(ctx, c, ref)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if config.Host != "" {
+			return config.Host, nil
+		}
+	}
+	if lastErr != nil {
+		return "", lastErr
+	}
+	return "", fmt.Errorf("no endpoint known for cluster %s/%s", cluster.Namespace, cluster.Name)
+}
@@ -0,0 +1,250 @@
+package accessutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+// newKubeConfig returns a kubeconfig for the server whose current user authenticates
+// with the client certificate, or with a token if certData is empty.
+func newKubeConfig(t *testing.T, server string, certData []byte) []byte {
+	t.Helper()
+	authInfo := &clientcmdapi.AuthInfo{ClientCertificateData: certData}
+	if len(certData) == 0 {
+		authInfo.Token = "token"
+	}
+	config := &clientcmdapi.Config{
+		Clusters:       map[string]*clientcmdapi.Cluster{"cluster": {Server: server, CertificateAuthorityData: []byte("ca")}},
+		AuthInfos:      map[string]*clientcmdapi.AuthInfo{"user": authInfo},
+		Contexts:       map[string]*clientcmdapi.Context{"context": {Cluster: "cluster", AuthInfo: "user"}},
+		CurrentContext: "context",
+	}
+	data, err := clientcmd.Write(*config)
+	if err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+	return data
+}
+
+func newSecret(name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "fleet", Name: name},
+		Data:       data,
+	}
+}
+
+func newFakeClient(objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objs...).Build()
+}
+
+func kubeConfigRef(name string) v1alpha1.AccessObjectRef {
+	return v1alpha1.AccessObjectRef{Type: v1alpha1.AccessTypeKubeConfig, Resource: "secrets", Name: name, Namespace: "fleet"}
+}
+
+func TestBuildRESTConfig(t *testing.T) {
+	fakeClient := newFakeClient(
+		newSecret("kubeconfig", map[string][]byte{v1alpha1.KubeConfigSecretKey: newKubeConfig(t, "https://api.example.com:6443", nil)}),
+		newSecret("no-key", map[string][]byte{"other": []byte("data")}),
+		newSecret("invalid", map[string][]byte{v1alpha1.KubeConfigSecretKey: []byte("not a kubeconfig")}),
+	)
+
+	cases := []struct {
+		name           string
+		ref            v1alpha1.AccessObjectRef
+		expectInsecure bool
+		expectError    bool
+	}{
+		{
+			name: "kubeconfig",
+			ref:  kubeConfigRef("kubeconfig"),
+		},
+		{
+			name: "insecure",
+			ref: func() v1alpha1.AccessObjectRef {
+				ref := kubeConfigRef("kubeconfig")
+				ref.TLSInsecureSkipVerify = true
+				return ref
+			}(),
+			expectInsecure: true,
+		},
+		{
+			name:        "missing secret",
+			ref:         kubeConfigRef("missing"),
+			expectError: true,
+		},
+		{
+			name:        "missing key",
+			ref:         kubeConfigRef("no-key"),
+			expectError: true,
+		},
+		{
+			name:        "invalid kubeconfig",
+			ref:         kubeConfigRef("invalid"),
+			expectError: true,
+		},
+		{
+			name: "not a secret",
+			ref: func() v1alpha1.AccessObjectRef {
+				ref := kubeConfigRef("kubeconfig")
+				ref.Resource = "configmaps"
+				return ref
+			}(),
+			expectError: true,
+		},
+		{
+			name: "external secret",
+			ref: v1alpha1.AccessObjectRef{
+				Type:              v1alpha1.AccessTypeKubeConfig,
+				ExternalSecretRef: &v1alpha1.ExternalSecretRef{Provider: v1alpha1.ExternalSecretProviderVault, Path: "kubeconfig"},
+			},
+			expectError: true,
+		},
+		{
+			name:        "no provider for type",
+			ref:         v1alpha1.AccessObjectRef{Type: v1alpha1.AccessTypeToken, Resource: "secrets", Name: "kubeconfig", Namespace: "fleet"},
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config, err := BuildRESTConfig(context.Background(), fakeClient, c.ref)
+			if c.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", config)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if config.Host != "https://api.example.com:6443" || config.BearerToken != "token" {
+				t.Errorf("expected the config of the kubeconfig, got %v", config)
+			}
+			if config.Insecure != c.expectInsecure || (len(config.CAData) == 0) != c.expectInsecure {
+				t.Errorf("expected insecure %v, got insecure %v with CA %q", c.expectInsecure, config.Insecure, config.CAData)
+			}
+		})
+	}
+}
+
+// fakeProvider returns a fixed REST config, or err.
+type fakeProvider struct {
+	host string
+	err  error
+}
+
+func (p *fakeProvider) RESTConfig(_ context.Context, _ client.Client, _ v1alpha1.AccessObjectRef) (*rest.Config, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &rest.Config{Host: p.host}, nil
+}
+
+// registerCredentialProvider registers the provider for the duration of the test.
+func registerCredentialProvider(t *testing.T, accessType v1alpha1.AccessType, p CredentialProvider) {
+	providersLock.RLock()
+	previous, ok := providers[accessType]
+	providersLock.RUnlock()
+	t.Cleanup(func() {
+		providersLock.Lock()
+		defer providersLock.Unlock()
+		if ok {
+			providers[accessType] = previous
+			return
+		}
+		delete(providers, accessType)
+	})
+	RegisterCredentialProvider(accessType, p)
+}
+
+func TestRegisterCredentialProvider(t *testing.T) {
+	registerCredentialProvider(t, v1alpha1.AccessTypeToken, &fakeProvider{host: "https://token.example.com"})
+
+	config, err := BuildRESTConfig(context.Background(), newFakeClient(), v1alpha1.AccessObjectRef{Type: v1alpha1.AccessTypeToken})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Host != "https://token.example.com" {
+		t.Errorf("expected the config of the registered provider, got %v", config)
+	}
+
+	// Registering a provider replaces the built-in one.
+	registerCredentialProvider(t, v1alpha1.AccessTypeKubeConfig, &fakeProvider{host: "https://kubeconfig.example.com"})
+	config, err = BuildRESTConfig(context.Background(), newFakeClient(), kubeConfigRef("missing"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Host != "https://kubeconfig.example.com" {
+		t.Errorf("expected the config of the registered provider, got %v", config)
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	providerErr := errors.New("provider failed")
+	registerCredentialProvider(t, v1alpha1.AccessTypeToken, &fakeProvider{err: providerErr})
+	fakeClient := newFakeClient(newSecret("kubeconfig", map[string][]byte{v1alpha1.KubeConfigSecretKey: newKubeConfig(t, "https://api.example.com:6443", nil)}))
+	tokenRef := v1alpha1.AccessObjectRef{Type: v1alpha1.AccessTypeToken}
+
+	cases := []struct {
+		name        string
+		status      string
+		refs        []v1alpha1.AccessObjectRef
+		expected    string
+		expectedErr error
+		expectError bool
+	}{
+		{
+			name:     "status endpoint",
+			status:   "https://status.example.com",
+			refs:     []v1alpha1.AccessObjectRef{kubeConfigRef("kubeconfig")},
+			expected: "https://status.example.com",
+		},
+		{
+			name:     "first access ref yielding a host",
+			refs:     []v1alpha1.AccessObjectRef{tokenRef, kubeConfigRef("kubeconfig")},
+			expected: "https://api.example.com:6443",
+		},
+		{
+			name:        "last error is returned",
+			refs:        []v1alpha1.AccessObjectRef{kubeConfigRef("missing"), tokenRef},
+			expectedErr: providerErr,
+			expectError: true,
+		},
+		{
+			name:        "no access refs",
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "fleet", Name: "cluster1"}}
+			cluster.Status.ControlPlaneEndpoint = c.status
+			cluster.Spec.AccessObjectRefs = c.refs
+
+			endpoint, err := Endpoint(context.Background(), fakeClient, cluster)
+			if c.expectError != (err != nil) {
+				t.Fatalf("expected error %v, got %v", c.expectError, err)
+			}
+			if c.expectedErr != nil && !errors.Is(err, c.expectedErr) {
+				t.Errorf("expected %v, got %v", c.expectedErr, err)
+			}
+			if endpoint != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, endpoint)
+			}
+		})
+	}
+}
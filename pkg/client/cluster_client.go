@@ -0,0 +1,103 @@
+// Package client provides a typed client for the cluster inventory API.
+package client
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+// ClusterClient wraps a controller-runtime client with typed methods for clusters.
+type ClusterClient struct {
+	client crclient.WithWatch
+}
+
+// NewClusterClient returns a ClusterClient backed by the given client. The scheme of
+// the client must have the v1alpha1 types registered.
+func NewClusterClient(client crclient.WithWatch) *ClusterClient {
+	return &ClusterClient{client: client}
+}
+
+// ListOption configures a List or Watch call.
+type ListOption func(*listOptions)
+
+type listOptions struct {
+	labelSelector labels.Selector
+	fieldSelector fields.Selector
+}
+
+// WithLabelSelector restricts the returned clusters to those matching the selector.
+func WithLabelSelector(selector labels.Selector) ListOption {
+	return func(o *listOptions) {
+		o.labelSelector = selector
+	}
+}
+
+// WithFieldSelector restricts the returned clusters to those matching the selector.
+func WithFieldSelector(selector fields.Selector) ListOption {
+	return func(o *listOptions) {
+		o.fieldSelector = selector
+	}
+}
+
+func (o *listOptions) toListOptions(namespace string) []crclient.ListOption {
+	opts := []crclient.ListOption{crclient.InNamespace(namespace)}
+	if o.labelSelector != nil {
+		opts = append(opts, crclient.MatchingLabelsSelector{Selector: o.labelSelector})
+	}
+	if o.fieldSelector != nil {
+		opts = append(opts, crclient.MatchingFieldsSelector{Selector: o.fieldSelector})
+	}
+	return opts
+}
+
+// Get returns the cluster with the given name and namespace.
+func (c *ClusterClient) Get(ctx context.Context, name, namespace string) (*v1alpha1.Cluster, error) {
+	cluster := &v1alpha1.Cluster{}
+	if err := c.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, cluster); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+// List returns the clusters in the given namespace. An empty namespace lists clusters
+// across all namespaces.
+func (c *ClusterClient) List(ctx context.Context, namespace string, opts ...ListOption) (*v1alpha1.ClusterList, error) {
+	o := &listOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	clusters := &v1alpha1.ClusterList{}
+	if err := c.client.List(ctx, clusters, o.toListOptions(namespace)...); err != nil {
+		return nil, err
+	}
+	return clusters, nil
+}
+
+// UpdateStatus updates the status subresource of the cluster.
+func (c *ClusterClient) UpdateStatus(ctx context.Context, cluster *v1alpha1.Cluster) error {
+	return c.client.Status().Update(ctx, cluster)
+}
+
+// Patch applies a raw patch of the given type to the cluster. The cluster is updated
+// with the response from the server.
+func (c *ClusterClient) Patch(ctx context.Context, cluster *v1alpha1.Cluster, pt types.PatchType, data []byte) error {
+	return c.client.Patch(ctx, cluster, crclient.RawPatch(pt, data))
+}
+
+// Watch watches the clusters in the given namespace.
+func (c *ClusterClient) Watch(ctx context.Context, namespace string, opts ...ListOption) (watch.Interface, error) {
+	o := &listOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return c.client.Watch(ctx, &v1alpha1.ClusterList{}, o.toListOptions(namespace)...)
+}
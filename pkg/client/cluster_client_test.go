@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	return scheme
+}
+
+func newFakeClient(t *testing.T, clusters ...*v1alpha1.Cluster) crclient.WithWatch {
+	objs := make([]crclient.Object, 0, len(clusters))
+	for _, cluster := range clusters {
+		objs = append(objs, cluster)
+	}
+	return fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(objs...).
+		WithStatusSubresource(&v1alpha1.Cluster{}).
+		WithIndex(&v1alpha1.Cluster{}, "metadata.name", func(obj crclient.Object) []string {
+			return []string{obj.GetName()}
+		}).
+		Build()
+}
+
+func newCluster(namespace, name string, labels map[string]string) *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+	}
+}
+
+func TestGet(t *testing.T) {
+	c := NewClusterClient(newFakeClient(t, newCluster("fleet", "cluster1", nil)))
+
+	cluster, err := c.Get(context.Background(), "cluster1", "fleet")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cluster.Name != "cluster1" || cluster.Namespace != "fleet" {
+		t.Errorf("unexpected cluster %s/%s", cluster.Namespace, cluster.Name)
+	}
+
+	if _, err := c.Get(context.Background(), "missing", "fleet"); !apierrors.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestList(t *testing.T) {
+	c := NewClusterClient(newFakeClient(t,
+		newCluster("fleet", "cluster1", map[string]string{"env": "prod"}),
+		newCluster("fleet", "cluster2", map[string]string{"env": "dev"}),
+		newCluster("other", "cluster3", map[string]string{"env": "prod"}),
+	))
+
+	cases := []struct {
+		name      string
+		namespace string
+		opts      []ListOption
+		expected  []string
+	}{
+		{
+			name:      "namespace",
+			namespace: "fleet",
+			expected:  []string{"cluster1", "cluster2"},
+		},
+		{
+			name:     "all namespaces",
+			expected: []string{"cluster1", "cluster2", "cluster3"},
+		},
+		{
+			name:     "label selector",
+			opts:     []ListOption{WithLabelSelector(labels.SelectorFromSet(labels.Set{"env": "prod"}))},
+			expected: []string{"cluster1", "cluster3"},
+		},
+		{
+			name:      "label selector in namespace",
+			namespace: "fleet",
+			opts:      []ListOption{WithLabelSelector(labels.SelectorFromSet(labels.Set{"env": "prod"}))},
+			expected:  []string{"cluster1"},
+		},
+		{
+			name:     "field selector",
+			opts:     []ListOption{WithFieldSelector(fields.OneTermEqualSelector("metadata.name", "cluster2"))},
+			expected: []string{"cluster2"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			list, err := c.List(context.Background(), tc.namespace, tc.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var names []string
+			for _, cluster := range list.Items {
+				names = append(names, cluster.Name)
+			}
+			if len(names) != len(tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, names)
+			}
+			for i := range names {
+				if names[i] != tc.expected[i] {
+					t.Errorf("expected %v, got %v", tc.expected, names)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	c := NewClusterClient(newFakeClient(t, newCluster("fleet", "cluster1", nil)))
+
+	cluster, err := c.Get(context.Background(), "cluster1", "fleet")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cluster.Status.Version.Kubernetes = "v1.28.0"
+	if err := c.UpdateStatus(context.Background(), cluster); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated, err := c.Get(context.Background(), "cluster1", "fleet")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Status.Version.Kubernetes != "v1.28.0" {
+		t.Errorf("expected status to be updated, got %#v", updated.Status.Version)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	c := NewClusterClient(newFakeClient(t, newCluster("fleet", "cluster1", nil)))
+
+	cluster := newCluster("fleet", "cluster1", nil)
+	if err := c.Patch(context.Background(), cluster, types.MergePatchType, []byte(`{"metadata":{"labels":{"env":"prod"}}}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cluster.Labels["env"] != "prod" {
+		t.Errorf("expected the cluster to be updated from the response, got labels %v", cluster.Labels)
+	}
+
+	patched, err := c.Get(context.Background(), "cluster1", "fleet")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if patched.Labels["env"] != "prod" {
+		t.Errorf("expected the patch to be applied, got labels %v", patched.Labels)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	fakeClient := newFakeClient(t)
+	c := NewClusterClient(fakeClient)
+
+	w, err := c.Watch(context.Background(), "fleet")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer w.Stop()
+
+	if err := fakeClient.Create(context.Background(), newCluster("fleet", "cluster1", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	event := <-w.ResultChan()
+	if event.Type != watch.Added {
+		t.Fatalf("expected an added event, got %v", event.Type)
+	}
+	if cluster, ok := event.Object.(*v1alpha1.Cluster); !ok || cluster.Name != "cluster1" {
+		t.Errorf("expected cluster1, got %#v", event.Object)
+	}
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+// DefaultStatusPatchConcurrency is the number of status patches issued in parallel by
+// ApplyStatusPatches unless configured otherwise.
+const DefaultStatusPatchConcurrency = 10
+
+// StatusPatchOption configures an ApplyStatusPatches call.
+type StatusPatchOption func(*statusPatchOptions)
+
+type statusPatchOptions struct {
+	concurrency int
+}
+
+// WithConcurrency sets the number of status patches issued in parallel. Values below 1
+// are ignored.
+func WithConcurrency(concurrency int) StatusPatchOption {
+	return func(o *statusPatchOptions) {
+		if concurrency > 0 {
+			o.concurrency = concurrency
+		}
+	}
+}
+
+// ApplyStatusPatches calls mutate on each cluster and patches its status subresource
+// with a JSON merge patch from the cluster before mutate to the cluster after, so that
+// fields cleared by mutate are removed on the server too. Patches are issued by a
+// bounded pool of workers, which call mutate concurrently, and a failed patch does not
+// stop the others; the errors of all failed patches are returned as an aggregate. Each
+// cluster is updated with the response from the server.
+func ApplyStatusPatches(ctx context.Context, c crclient.Client, clusters []*v1alpha1.Cluster, mutate func(*v1alpha1.Cluster), opts ...StatusPatchOption) error {
+	o := &statusPatchOptions{concurrency: DefaultStatusPatchConcurrency}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	work := make(chan *v1alpha1.Cluster)
+	for i := 0; i < o.concurrency && i < len(clusters); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for cluster := range work {
+				original := cluster.DeepCopy()
+				mutate(cluster)
+				if err := c.Status().Patch(ctx, cluster, crclient.MergeFrom(original)); err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Errorf("failed to patch status of cluster %s/%s: %w", cluster.Namespace, cluster.Name, err))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for _, cluster := range clusters {
+		work <- cluster
+	}
+	close(work)
+	wg.Wait()
+
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+func TestApplyStatusPatches(t *testing.T) {
+	cases := []struct {
+		name           string
+		existing       int
+		missing        []string
+		opts           []StatusPatchOption
+		expectedErrors int
+	}{
+		{
+			name: "no clusters",
+		},
+		{
+			name:     "all patched",
+			existing: 25,
+		},
+		{
+			name:     "sequential",
+			existing: 3,
+			opts:     []StatusPatchOption{WithConcurrency(1)},
+		},
+		{
+			name:     "invalid concurrency is ignored",
+			existing: 3,
+			opts:     []StatusPatchOption{WithConcurrency(0)},
+		},
+		{
+			name:           "failures do not stop other patches",
+			existing:       5,
+			missing:        []string{"missing1", "missing2"},
+			opts:           []StatusPatchOption{WithConcurrency(2)},
+			expectedErrors: 2,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var existing, clusters []*v1alpha1.Cluster
+			for i := 0; i < c.existing; i++ {
+				cluster := newCluster("fleet", fmt.Sprintf("cluster%d", i), nil)
+				cluster.Status.Properties = []v1alpha1.Property{{Name: "example.com/stale", Value: "true"}}
+				existing = append(existing, cluster)
+			}
+			fakeClient := newFakeClient(t, existing...)
+			for _, cluster := range existing {
+				clusters = append(clusters, cluster.DeepCopy())
+			}
+			for _, name := range c.missing {
+				clusters = append(clusters, newCluster("fleet", name, nil))
+			}
+
+			// The mutation clears the properties, which must be removed on the server too.
+			err := ApplyStatusPatches(context.Background(), fakeClient, clusters, func(cluster *v1alpha1.Cluster) {
+				cluster.Status.Version.Kubernetes = "v1.28.0"
+				cluster.Status.Properties = nil
+			}, c.opts...)
+			if c.expectedErrors == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c.expectedErrors > 0 {
+				var agg utilerrors.Aggregate
+				if !errors.As(err, &agg) || len(agg.Errors()) != c.expectedErrors {
+					t.Fatalf("expected %d errors, got %v", c.expectedErrors, err)
+				}
+				for _, name := range c.missing {
+					if !strings.Contains(err.Error(), "fleet/"+name) {
+						t.Errorf("expected an error for cluster %s, got %v", name, err)
+					}
+				}
+			}
+
+			clusterClient := NewClusterClient(fakeClient)
+			for _, cluster := range existing {
+				patched, err := clusterClient.Get(context.Background(), cluster.Name, cluster.Namespace)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if patched.Status.Version.Kubernetes != "v1.28.0" {
+					t.Errorf("expected the status of %s to be patched, got %#v", cluster.Name, patched.Status.Version)
+				}
+				if len(patched.Status.Properties) != 0 {
+					t.Errorf("expected the properties of %s to be removed, got %v", cluster.Name, patched.Status.Properties)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Package health evaluates the health of a cluster from the heartbeats of its agent.
+package health
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+// DefaultJitterFraction is the fraction of the heartbeat interval a heartbeat may be
+// late by and still be considered on time, when none is specified.
+const DefaultJitterFraction = v1alpha1.DefaultHeartbeatJitterFraction
+
+const (
+	// ReasonHeartbeatOnTime means the last heartbeat of the agent arrived in time.
+	ReasonHeartbeatOnTime = "HeartbeatOnTime"
+	// ReasonHeartbeatLate means the agent missed its last heartbeat.
+	ReasonHeartbeatLate = "HeartbeatLate"
+	// ReasonNoHeartbeat means the agent has never reported a successful heartbeat.
+	ReasonNoHeartbeat = "NoHeartbeat"
+)
+
+// Options configures the health evaluation.
+type Options struct {
+	// JitterFraction is the fraction of the heartbeat interval a heartbeat may be late
+	// by and still be considered on time, so that heartbeats arriving slightly late do
+	// not flip the health of the cluster. Zero or a negative value means
+	// DefaultJitterFraction.
+	JitterFraction float64
+}
+
+// Evaluate returns the Healthy condition of the cluster at now, with the default
+// options.
+func Evaluate(c *v1alpha1.Cluster, now time.Time) metav1.Condition {
+	return EvaluateWithOptions(c, now, Options{})
+}
+
+// EvaluateWithOptions returns the Healthy condition of the cluster at now. The cluster
+// is healthy if its last successful heartbeat is no older than the heartbeat interval
+// multiplied by 1 plus the jitter fraction, unhealthy if it is older and unknown if the
+// agent never reported a successful heartbeat. While the health probe is suspended,
+// the status of the current Healthy condition is kept, Unknown if there is none, with
+// the ProbingSuspended reason.
+func EvaluateWithOptions(c *v1alpha1.Cluster, now time.Time, opts Options) metav1.Condition {
+	if c.Spec.HealthProbe.IsSuspended() {
+		return metav1.Condition{
+			Type:    v1alpha1.ClusterConditionHealthy,
+			Status:  c.ConditionStatus(v1alpha1.ClusterConditionHealthy),
+			Reason:  v1alpha1.ConditionReasonProbingSuspended,
+			Message: "The health probe is suspended",
+		}
+	}
+
+	jitter := opts.JitterFraction
+	if jitter <= 0 {
+		jitter = DefaultJitterFraction
+	}
+
+	condition := metav1.Condition{Type: v1alpha1.ClusterConditionHealthy}
+	deadline, ok := c.HeartbeatDeadline(jitter)
+	if !ok {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = ReasonNoHeartbeat
+		condition.Message = "The agent has not reported a successful heartbeat"
+		return condition
+	}
+	if now.After(deadline) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonHeartbeatLate
+		condition.Message = fmt.Sprintf("The last successful heartbeat was at %s",
+			c.Status.AgentStatus.LastSuccessfulHeartbeatTime.UTC().Format(time.RFC3339))
+		return condition
+	}
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = ReasonHeartbeatOnTime
+	condition.Message = "The agent heartbeats are on time"
+	return condition
+}
@@ -0,0 +1,136 @@
+package health
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+func newCluster(lastHeartbeat *time.Time) *v1alpha1.Cluster {
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{HealthProbe: v1alpha1.HealthProbe{HeartbeatIntervalSeconds: 60}},
+	}
+	if lastHeartbeat != nil {
+		t := metav1.NewTime(*lastHeartbeat)
+		cluster.Status.AgentStatus = &v1alpha1.AgentStatus{LastSuccessfulHeartbeatTime: &t}
+	}
+	return cluster
+}
+
+func TestEvaluateWithOptions(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	cases := []struct {
+		name           string
+		lastHeartbeat  *time.Time
+		opts           Options
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "no heartbeat",
+			expectedStatus: metav1.ConditionUnknown,
+			expectedReason: ReasonNoHeartbeat,
+		},
+		{
+			name:           "within the interval",
+			lastHeartbeat:  ago(30 * time.Second),
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: ReasonHeartbeatOnTime,
+		},
+		{
+			name:           "late within the default jitter",
+			lastHeartbeat:  ago(70 * time.Second),
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: ReasonHeartbeatOnTime,
+		},
+		{
+			name:           "at the jitter boundary",
+			lastHeartbeat:  ago(72 * time.Second),
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: ReasonHeartbeatOnTime,
+		},
+		{
+			name:           "just past the jitter boundary",
+			lastHeartbeat:  ago(72*time.Second + time.Millisecond),
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: ReasonHeartbeatLate,
+		},
+		{
+			name:           "custom jitter",
+			lastHeartbeat:  ago(72 * time.Second),
+			opts:           Options{JitterFraction: 0.1},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: ReasonHeartbeatLate,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			condition := EvaluateWithOptions(newCluster(c.lastHeartbeat), now, c.opts)
+			if condition.Type != v1alpha1.ClusterConditionHealthy {
+				t.Errorf("expected a %s condition, got %s", v1alpha1.ClusterConditionHealthy, condition.Type)
+			}
+			if condition.Status != c.expectedStatus || condition.Reason != c.expectedReason {
+				t.Errorf("expected %s/%s, got %s/%s", c.expectedStatus, c.expectedReason, condition.Status, condition.Reason)
+			}
+		})
+	}
+}
+
+func TestEvaluateSuspended(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := now.Add(-time.Hour)
+
+	cases := []struct {
+		name           string
+		healthy        *metav1.ConditionStatus
+		expectedStatus metav1.ConditionStatus
+	}{
+		{
+			name:           "available cluster stays available",
+			healthy:        statusPtr(metav1.ConditionTrue),
+			expectedStatus: metav1.ConditionTrue,
+		},
+		{
+			name:           "unavailable cluster stays unavailable",
+			healthy:        statusPtr(metav1.ConditionFalse),
+			expectedStatus: metav1.ConditionFalse,
+		},
+		{
+			name:           "no condition",
+			expectedStatus: metav1.ConditionUnknown,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster(&late)
+			cluster.Spec.HealthProbe.Suspend = true
+			if c.healthy != nil {
+				cluster.Status.Conditions = []metav1.Condition{{Type: v1alpha1.ClusterConditionHealthy, Status: *c.healthy, Reason: ReasonHeartbeatOnTime}}
+			}
+
+			condition := Evaluate(cluster, now)
+			if condition.Status != c.expectedStatus || condition.Reason != v1alpha1.ConditionReasonProbingSuspended {
+				t.Errorf("expected %s/%s, got %s/%s", c.expectedStatus, v1alpha1.ConditionReasonProbingSuspended, condition.Status, condition.Reason)
+			}
+
+			cluster.Spec.HealthProbe.Suspend = false
+			if condition := Evaluate(cluster, now); condition.Status != metav1.ConditionFalse {
+				t.Errorf("expected the late heartbeat to flip the cluster once resumed, got %s", condition.Status)
+			}
+		})
+	}
+}
+
+func statusPtr(s metav1.ConditionStatus) *metav1.ConditionStatus {
+	return &s
+}
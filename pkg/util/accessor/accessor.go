@@ -0,0 +1,101 @@
+// Package accessor provides typed access to commonly used nested fields of clusters.
+package accessor
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+	"github.com/qiujian16/cluster-inventory-api/pkg/util/condition"
+)
+
+// ClusterAccessor wraps a cluster with getters and setters for commonly used nested
+// fields. Getters return zero values and setters do nothing when the wrapped cluster is
+// nil.
+type ClusterAccessor struct {
+	cluster *v1alpha1.Cluster
+}
+
+// NewClusterAccessor returns an accessor of the cluster.
+func NewClusterAccessor(cluster *v1alpha1.Cluster) *ClusterAccessor {
+	return &ClusterAccessor{cluster: cluster}
+}
+
+// CPU returns the allocatable CPU of the cluster.
+func (a *ClusterAccessor) CPU() resource.Quantity {
+	return a.allocatable(v1alpha1.ResourceCPU)
+}
+
+// Memory returns the allocatable memory of the cluster.
+func (a *ClusterAccessor) Memory() resource.Quantity {
+	return a.allocatable(v1alpha1.ResourceMemory)
+}
+
+// KubernetesVersion returns the Kubernetes version of the cluster.
+func (a *ClusterAccessor) KubernetesVersion() string {
+	if a.cluster == nil {
+		return ""
+	}
+	return a.cluster.Status.Version.Kubernetes
+}
+
+// Phase returns the phase of the cluster, as returned by Cluster.Phase.
+func (a *ClusterAccessor) Phase() v1alpha1.ClusterPhase {
+	if a.cluster == nil {
+		return ""
+	}
+	return a.cluster.Phase()
+}
+
+// SetPhase sets the Joined and Healthy conditions of the cluster so that Phase returns
+// p, with p as their reason. A Pending cluster is not joined and its health is
+// unknown. Unknown phases are ignored.
+func (a *ClusterAccessor) SetPhase(p v1alpha1.ClusterPhase) {
+	if a.cluster == nil {
+		return
+	}
+	joined, healthy := metav1.ConditionTrue, metav1.ConditionUnknown
+	switch p {
+	case v1alpha1.ClusterPhasePending:
+		joined = metav1.ConditionFalse
+	case v1alpha1.ClusterPhaseReady:
+		healthy = metav1.ConditionTrue
+	case v1alpha1.ClusterPhaseUnhealthy:
+		healthy = metav1.ConditionFalse
+	case v1alpha1.ClusterPhaseUnknown:
+	default:
+		return
+	}
+	meta.SetStatusCondition(&a.cluster.Status.Conditions,
+		condition.NewCondition(v1alpha1.ClusterConditionJoined, string(joined), string(p), ""))
+	meta.SetStatusCondition(&a.cluster.Status.Conditions,
+		condition.NewCondition(v1alpha1.ClusterConditionHealthy, string(healthy), string(p), ""))
+}
+
+// SetCPUAllocatable sets the allocatable CPU of the cluster.
+func (a *ClusterAccessor) SetCPUAllocatable(q resource.Quantity) {
+	a.setAllocatable(v1alpha1.ResourceCPU, q)
+}
+
+// SetMemoryAllocatable sets the allocatable memory of the cluster.
+func (a *ClusterAccessor) SetMemoryAllocatable(q resource.Quantity) {
+	a.setAllocatable(v1alpha1.ResourceMemory, q)
+}
+
+func (a *ClusterAccessor) allocatable(name v1alpha1.ResourceName) resource.Quantity {
+	if a.cluster == nil {
+		return resource.Quantity{}
+	}
+	return a.cluster.Status.Resources.Allocatable[name].DeepCopy()
+}
+
+func (a *ClusterAccessor) setAllocatable(name v1alpha1.ResourceName, q resource.Quantity) {
+	if a.cluster == nil {
+		return
+	}
+	if a.cluster.Status.Resources.Allocatable == nil {
+		a.cluster.Status.Resources.Allocatable = v1alpha1.ResourceList{}
+	}
+	a.cluster.Status.Resources.Allocatable[name] = q
+}
@@ -0,0 +1,124 @@
+package accessor
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+func TestGetters(t *testing.T) {
+	cases := []struct {
+		name            string
+		cluster         *v1alpha1.Cluster
+		expectedCPU     string
+		expectedMemory  string
+		expectedVersion string
+		expectedPhase   v1alpha1.ClusterPhase
+	}{
+		{
+			name:           "nil cluster",
+			expectedCPU:    "0",
+			expectedMemory: "0",
+		},
+		{
+			name:           "empty status",
+			cluster:        &v1alpha1.Cluster{},
+			expectedCPU:    "0",
+			expectedMemory: "0",
+			expectedPhase:  v1alpha1.ClusterPhasePending,
+		},
+		{
+			name: "populated status",
+			cluster: &v1alpha1.Cluster{Status: v1alpha1.ClusterStatus{
+				Version: v1alpha1.ClusterVersion{Kubernetes: "v1.28.0"},
+				Resources: v1alpha1.Resources{Allocatable: v1alpha1.ResourceList{
+					v1alpha1.ResourceCPU:    resource.MustParse("3500m"),
+					v1alpha1.ResourceMemory: resource.MustParse("16Gi"),
+				}},
+				Conditions: []metav1.Condition{
+					{Type: v1alpha1.ClusterConditionJoined, Status: metav1.ConditionTrue},
+					{Type: v1alpha1.ClusterConditionHealthy, Status: metav1.ConditionTrue},
+				},
+			}},
+			expectedCPU:     "3500m",
+			expectedMemory:  "16Gi",
+			expectedVersion: "v1.28.0",
+			expectedPhase:   v1alpha1.ClusterPhaseReady,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a := NewClusterAccessor(c.cluster)
+			if cpu := a.CPU(); cpu.String() != c.expectedCPU {
+				t.Errorf("expected cpu %s, got %s", c.expectedCPU, cpu.String())
+			}
+			if memory := a.Memory(); memory.String() != c.expectedMemory {
+				t.Errorf("expected memory %s, got %s", c.expectedMemory, memory.String())
+			}
+			if version := a.KubernetesVersion(); version != c.expectedVersion {
+				t.Errorf("expected version %q, got %q", c.expectedVersion, version)
+			}
+			if phase := a.Phase(); phase != c.expectedPhase {
+				t.Errorf("expected phase %q, got %q", c.expectedPhase, phase)
+			}
+		})
+	}
+}
+
+func TestSetPhase(t *testing.T) {
+	phases := []v1alpha1.ClusterPhase{v1alpha1.ClusterPhasePending, v1alpha1.ClusterPhaseReady, v1alpha1.ClusterPhaseUnhealthy, v1alpha1.ClusterPhaseUnknown}
+
+	for _, from := range phases {
+		for _, to := range phases {
+			t.Run(string(from)+" to "+string(to), func(t *testing.T) {
+				cluster := &v1alpha1.Cluster{}
+				a := NewClusterAccessor(cluster)
+				a.SetPhase(from)
+				a.SetPhase(to)
+				if phase := a.Phase(); phase != to {
+					t.Errorf("expected %q, got %q", to, phase)
+				}
+				if len(cluster.Status.Conditions) != 2 {
+					t.Errorf("expected the joined and healthy conditions, got %v", cluster.Status.Conditions)
+				}
+			})
+		}
+	}
+
+	t.Run("invalid phase", func(t *testing.T) {
+		cluster := &v1alpha1.Cluster{}
+		a := NewClusterAccessor(cluster)
+		a.SetPhase(v1alpha1.ClusterPhaseReady)
+		a.SetPhase("Deleting")
+		if phase := a.Phase(); phase != v1alpha1.ClusterPhaseReady {
+			t.Errorf("expected the phase to be unchanged, got %q", phase)
+		}
+	})
+}
+
+func TestSetters(t *testing.T) {
+	cluster := &v1alpha1.Cluster{}
+	a := NewClusterAccessor(cluster)
+	a.SetCPUAllocatable(resource.MustParse("4"))
+	a.SetMemoryAllocatable(resource.MustParse("8Gi"))
+
+	if cpu := cluster.Status.Resources.Allocatable[v1alpha1.ResourceCPU]; cpu.String() != "4" {
+		t.Errorf("expected the cluster cpu to be set, got %s", cpu.String())
+	}
+	if memory := cluster.Status.Resources.Allocatable[v1alpha1.ResourceMemory]; memory.String() != "8Gi" {
+		t.Errorf("expected the cluster memory to be set, got %s", memory.String())
+	}
+
+	// Setters of an accessor of a nil cluster do nothing.
+	nilAccessor := NewClusterAccessor(nil)
+	nilAccessor.SetCPUAllocatable(resource.MustParse("4"))
+	nilAccessor.SetMemoryAllocatable(resource.MustParse("8Gi"))
+	nilAccessor.SetPhase(v1alpha1.ClusterPhaseReady)
+	if phase := nilAccessor.Phase(); phase != "" {
+		t.Errorf("expected no phase, got %q", phase)
+	}
+}
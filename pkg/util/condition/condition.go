@@ -0,0 +1,63 @@
+// Package condition contains helpers to build and compare cluster conditions.
+package condition
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewCondition returns a condition with the given type, status, reason and message.
+// The last transition time is left unset, so that meta.SetStatusCondition sets it when
+// the status changes.
+func NewCondition(condType, status, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    condType,
+		Status:  metav1.ConditionStatus(status),
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// True returns a condition with the given type, reason and message and status True.
+func True(condType, reason, message string) metav1.Condition {
+	return NewCondition(condType, string(metav1.ConditionTrue), reason, message)
+}
+
+// False returns a condition with the given type, reason and message and status False.
+func False(condType, reason, message string) metav1.Condition {
+	return NewCondition(condType, string(metav1.ConditionFalse), reason, message)
+}
+
+// Unknown returns a condition with the given type, reason and message and status
+// Unknown.
+func Unknown(condType, reason, message string) metav1.Condition {
+	return NewCondition(condType, string(metav1.ConditionUnknown), reason, message)
+}
+
+// ConditionDiff compares two condition lists by type. It returns the conditions of new
+// whose type is not in old, the conditions of old whose type is not in new, and the
+// conditions of new whose status, reason, message or observed generation differs from
+// the condition of the same type in old. The last transition time is ignored.
+func ConditionDiff(old, new []metav1.Condition) (added, removed, updated []metav1.Condition) {
+	oldByType := make(map[string]metav1.Condition, len(old))
+	for _, c := range old {
+		oldByType[c.Type] = c
+	}
+	newTypes := make(map[string]struct{}, len(new))
+	for _, c := range new {
+		newTypes[c.Type] = struct{}{}
+		o, ok := oldByType[c.Type]
+		switch {
+		case !ok:
+			added = append(added, c)
+		case o.Status != c.Status || o.Reason != c.Reason || o.Message != c.Message ||
+			o.ObservedGeneration != c.ObservedGeneration:
+			updated = append(updated, c)
+		}
+	}
+	for _, c := range old {
+		if _, ok := newTypes[c.Type]; !ok {
+			removed = append(removed, c)
+		}
+	}
+	return added, removed, updated
+}
@@ -0,0 +1,119 @@
+package condition
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuilders(t *testing.T) {
+	cases := []struct {
+		name     string
+		build    func(condType, reason, message string) metav1.Condition
+		expected metav1.ConditionStatus
+	}{
+		{name: "true", build: True, expected: metav1.ConditionTrue},
+		{name: "false", build: False, expected: metav1.ConditionFalse},
+		{name: "unknown", build: Unknown, expected: metav1.ConditionUnknown},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cond := c.build("Joined", "Reason", "message")
+			expected := metav1.Condition{Type: "Joined", Status: c.expected, Reason: "Reason", Message: "message"}
+			if cond != expected {
+				t.Errorf("expected %v, got %v", expected, cond)
+			}
+		})
+	}
+}
+
+func TestConditionDiff(t *testing.T) {
+	joined := True("Joined", "Joined", "")
+	healthy := True("Healthy", "Healthy", "")
+
+	cases := []struct {
+		name            string
+		old             []metav1.Condition
+		new             []metav1.Condition
+		expectedAdded   []string
+		expectedRemoved []string
+		expectedUpdated []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "unchanged",
+			old:  []metav1.Condition{joined, healthy},
+			new:  []metav1.Condition{healthy, joined},
+		},
+		{
+			name: "last transition time is ignored",
+			old:  []metav1.Condition{joined},
+			new: []metav1.Condition{func() metav1.Condition {
+				c := joined
+				c.LastTransitionTime = metav1.NewTime(time.Now())
+				return c
+			}()},
+		},
+		{
+			name:          "added",
+			old:           []metav1.Condition{joined},
+			new:           []metav1.Condition{joined, healthy},
+			expectedAdded: []string{"Healthy"},
+		},
+		{
+			name:            "removed",
+			old:             []metav1.Condition{joined, healthy},
+			new:             []metav1.Condition{joined},
+			expectedRemoved: []string{"Healthy"},
+		},
+		{
+			name:            "status, reason, message and generation changes",
+			old:             []metav1.Condition{joined, healthy, True("A", "Reason", ""), True("B", "Reason", "")},
+			new:             []metav1.Condition{joined, False("Healthy", "Healthy", ""), True("A", "Other", ""), True("B", "Reason", "message")},
+			expectedUpdated: []string{"Healthy", "A", "B"},
+		},
+		{
+			name: "observed generation change",
+			old:  []metav1.Condition{joined},
+			new: []metav1.Condition{func() metav1.Condition {
+				c := joined
+				c.ObservedGeneration = 2
+				return c
+			}()},
+			expectedUpdated: []string{"Joined"},
+		},
+		{
+			name:            "added, removed and updated",
+			old:             []metav1.Condition{joined, True("Gone", "Reason", "")},
+			new:             []metav1.Condition{Unknown("Joined", "Joined", ""), healthy},
+			expectedAdded:   []string{"Healthy"},
+			expectedRemoved: []string{"Gone"},
+			expectedUpdated: []string{"Joined"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			added, removed, updated := ConditionDiff(c.old, c.new)
+			assertTypes(t, "added", added, c.expectedAdded)
+			assertTypes(t, "removed", removed, c.expectedRemoved)
+			assertTypes(t, "updated", updated, c.expectedUpdated)
+		})
+	}
+}
+
+func assertTypes(t *testing.T, kind string, conditions []metav1.Condition, expected []string) {
+	t.Helper()
+	var types []string
+	for _, c := range conditions {
+		types = append(types, c.Type)
+	}
+	if strings.Join(types, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %s %v, got %v", kind, expected, types)
+	}
+}
@@ -0,0 +1,65 @@
+package util
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+// WatchCondition calls handler with every cluster of the informer when it is added,
+// including during the initial sync, and whenever the status or reason of its
+// condition of the given type changes. The handler receives the condition before and
+// after the change; the old condition is always nil when the cluster is added, and
+// either condition is nil if the cluster has no such condition. The handler is removed
+// from the informer when the context is done.
+func WatchCondition(ctx context.Context, informer cache.SharedIndexInformer, condType string, handler func(cluster *v1alpha1.Cluster, oldCondition, newCondition *metav1.Condition)) {
+	registration, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			cluster, ok := obj.(*v1alpha1.Cluster)
+			if !ok {
+				return
+			}
+			handler(cluster, nil, meta.FindStatusCondition(cluster.Status.Conditions, condType))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldCluster, ok := oldObj.(*v1alpha1.Cluster)
+			if !ok {
+				return
+			}
+			newCluster, ok := newObj.(*v1alpha1.Cluster)
+			if !ok {
+				return
+			}
+			oldCondition := meta.FindStatusCondition(oldCluster.Status.Conditions, condType)
+			newCondition := meta.FindStatusCondition(newCluster.Status.Conditions, condType)
+			if conditionChanged(oldCondition, newCondition) {
+				handler(newCluster, oldCondition, newCondition)
+			}
+		},
+	})
+	if err != nil {
+		// Handlers can only fail to be added to a stopped informer, which will not
+		// deliver any event anyway.
+		utilruntime.HandleError(err)
+		return
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = informer.RemoveEventHandler(registration)
+	}()
+}
+
+// conditionChanged returns true if the condition was added or removed, or if its status
+// or reason changed.
+func conditionChanged(oldCondition, newCondition *metav1.Condition) bool {
+	if oldCondition == nil || newCondition == nil {
+		return oldCondition != newCondition
+	}
+	return oldCondition.Status != newCondition.Status || oldCondition.Reason != newCondition.Reason
+}
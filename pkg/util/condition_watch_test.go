@@ -0,0 +1,114 @@
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	fcache "k8s.io/client-go/tools/cache/testing"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+type conditionEvent struct {
+	cluster      string
+	oldCondition *metav1.Condition
+	newCondition *metav1.Condition
+}
+
+func TestWatchCondition(t *testing.T) {
+	healthy := newCondition(v1alpha1.ClusterConditionHealthy, metav1.ConditionTrue, "Healthy")
+	unhealthy := newCondition(v1alpha1.ClusterConditionHealthy, metav1.ConditionFalse, "Unhealthy")
+	joined := newCondition(v1alpha1.ClusterConditionJoined, metav1.ConditionTrue, "Joined")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source := fcache.NewFakeControllerSource()
+	cluster1 := newCluster(healthy)
+	cluster1.Namespace = "fleet"
+	source.Add(cluster1)
+
+	informer := cache.NewSharedIndexInformer(source, &v1alpha1.Cluster{}, 0, cache.Indexers{})
+	events := make(chan conditionEvent, 10)
+	WatchCondition(ctx, informer, v1alpha1.ClusterConditionHealthy, func(cluster *v1alpha1.Cluster, oldCondition, newCondition *metav1.Condition) {
+		events <- conditionEvent{cluster: cluster.Name, oldCondition: oldCondition, newCondition: newCondition}
+	})
+	go informer.Run(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		t.Fatalf("failed to sync informer")
+	}
+
+	// The initial sync fires without an old condition.
+	assertConditionEvent(t, events, "cluster1", nil, &healthy)
+
+	// Updates leaving the status and reason of the condition unchanged don't fire, so
+	// the next event must be the transition below.
+	noop := newCluster(newCondition(v1alpha1.ClusterConditionHealthy, metav1.ConditionTrue, "Healthy"), joined)
+	noop.Namespace = "fleet"
+	noop.Status.Conditions[0].Message = "still healthy"
+	source.Modify(noop)
+
+	transition := newCluster(unhealthy, joined)
+	transition.Namespace = "fleet"
+	source.Modify(transition)
+	assertConditionEvent(t, events, "cluster1", &healthy, &unhealthy)
+
+	// Removing the condition fires without a new condition.
+	removed := newCluster(joined)
+	removed.Namespace = "fleet"
+	source.Modify(removed)
+	assertConditionEvent(t, events, "cluster1", &unhealthy, nil)
+
+	// Clusters added after the initial sync fire as well, even without the condition.
+	cluster2 := newCluster()
+	cluster2.Name = "cluster2"
+	cluster2.Namespace = "fleet"
+	source.Add(cluster2)
+	assertConditionEvent(t, events, "cluster2", nil, nil)
+}
+
+func TestWatchConditionStoppedInformer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	informer := cache.NewSharedIndexInformer(fcache.NewFakeControllerSource(), &v1alpha1.Cluster{}, 0, cache.Indexers{})
+	stopCh := make(chan struct{})
+	close(stopCh)
+	informer.Run(stopCh)
+
+	// Watching a stopped informer is a no-op.
+	WatchCondition(ctx, informer, v1alpha1.ClusterConditionHealthy, func(*v1alpha1.Cluster, *metav1.Condition, *metav1.Condition) {
+		t.Errorf("unexpected handler call")
+	})
+}
+
+func assertConditionEvent(t *testing.T, events <-chan conditionEvent, cluster string, oldCondition, newCondition *metav1.Condition) {
+	t.Helper()
+	select {
+	case event := <-events:
+		if event.cluster != cluster {
+			t.Errorf("expected cluster %q, got %q", cluster, event.cluster)
+		}
+		assertCondition(t, "old", oldCondition, event.oldCondition)
+		assertCondition(t, "new", newCondition, event.newCondition)
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("timed out waiting for the handler of cluster %q", cluster)
+	}
+}
+
+func assertCondition(t *testing.T, name string, expected, actual *metav1.Condition) {
+	t.Helper()
+	if expected == nil || actual == nil {
+		if expected != actual {
+			t.Errorf("expected %s condition %v, got %v", name, expected, actual)
+		}
+		return
+	}
+	if expected.Status != actual.Status || expected.Reason != actual.Reason {
+		t.Errorf("expected %s condition %v, got %v", name, *expected, *actual)
+	}
+}
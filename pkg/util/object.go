@@ -0,0 +1,53 @@
+package util
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+// UnexpectedTypeError is returned when an object is not of the expected type.
+type UnexpectedTypeError struct {
+	// Expected is the expected type of the object.
+	Expected string
+	// Actual is the type of the object, or "nil" for a nil object.
+	Actual string
+}
+
+func (e *UnexpectedTypeError) Error() string {
+	return fmt.Sprintf("expected object of type %s, got %s", e.Expected, e.Actual)
+}
+
+// ClusterFromObject returns the object as a cluster. An *UnexpectedTypeError is
+// returned if the object is nil or not a cluster.
+func ClusterFromObject(obj client.Object) (*v1alpha1.Cluster, error) {
+	cluster, ok := obj.(*v1alpha1.Cluster)
+	if !ok || cluster == nil {
+		return nil, newUnexpectedTypeError(cluster, obj, ok)
+	}
+	return cluster, nil
+}
+
+// ClusterListFromObject returns the object as a cluster list. Lists do not implement
+// client.Object, so the object is a client.ObjectList. An *UnexpectedTypeError is
+// returned if the object is nil or not a cluster list.
+func ClusterListFromObject(obj client.ObjectList) (*v1alpha1.ClusterList, error) {
+	list, ok := obj.(*v1alpha1.ClusterList)
+	if !ok || list == nil {
+		return nil, newUnexpectedTypeError(list, obj, ok)
+	}
+	return list, nil
+}
+
+// newUnexpectedTypeError returns the error for obj failing the assertion to the type
+// of expected. A nil obj, including a nil pointer of the expected type, is reported as
+// nil.
+func newUnexpectedTypeError(expected, obj interface{}, isExpectedType bool) error {
+	actual := "nil"
+	if obj != nil && !isExpectedType {
+		actual = fmt.Sprintf("%T", obj)
+	}
+	return &UnexpectedTypeError{Expected: fmt.Sprintf("%T", expected), Actual: actual}
+}
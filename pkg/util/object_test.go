@@ -0,0 +1,108 @@
+package util
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+func TestClusterFromObject(t *testing.T) {
+	cluster := &v1alpha1.Cluster{}
+
+	cases := []struct {
+		name           string
+		obj            client.Object
+		expectedActual string
+	}{
+		{
+			name: "cluster",
+			obj:  cluster,
+		},
+		{
+			name:           "other type",
+			obj:            &corev1.Secret{},
+			expectedActual: "*v1.Secret",
+		},
+		{
+			name:           "nil cluster",
+			obj:            (*v1alpha1.Cluster)(nil),
+			expectedActual: "nil",
+		},
+		{
+			name:           "nil",
+			expectedActual: "nil",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := ClusterFromObject(c.obj)
+			assertUnexpectedTypeError(t, err, "*v1alpha1.Cluster", c.expectedActual)
+			if c.expectedActual == "" && actual != cluster {
+				t.Errorf("expected %p, got %p", cluster, actual)
+			}
+		})
+	}
+}
+
+func TestClusterListFromObject(t *testing.T) {
+	list := &v1alpha1.ClusterList{}
+
+	cases := []struct {
+		name           string
+		obj            client.ObjectList
+		expectedActual string
+	}{
+		{
+			name: "cluster list",
+			obj:  list,
+		},
+		{
+			name:           "other type",
+			obj:            &corev1.SecretList{},
+			expectedActual: "*v1.SecretList",
+		},
+		{
+			name:           "nil cluster list",
+			obj:            (*v1alpha1.ClusterList)(nil),
+			expectedActual: "nil",
+		},
+		{
+			name:           "nil",
+			expectedActual: "nil",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := ClusterListFromObject(c.obj)
+			assertUnexpectedTypeError(t, err, "*v1alpha1.ClusterList", c.expectedActual)
+			if c.expectedActual == "" && actual != list {
+				t.Errorf("expected %p, got %p", list, actual)
+			}
+		})
+	}
+}
+
+// assertUnexpectedTypeError checks that err is nil if expectedActual is empty, or an
+// *UnexpectedTypeError with the expected and actual types otherwise.
+func assertUnexpectedTypeError(t *testing.T, err error, expected, expectedActual string) {
+	t.Helper()
+	if expectedActual == "" {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	var typeErr *UnexpectedTypeError
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("expected an *UnexpectedTypeError, got %v", err)
+	}
+	if typeErr.Expected != expected || typeErr.Actual != expectedActual {
+		t.Errorf("expected (%s, %s), got (%s, %s)", expected, expectedActual, typeErr.Expected, typeErr.Actual)
+	}
+}
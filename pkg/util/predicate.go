@@ -0,0 +1,28 @@
+// Package util contains helpers for controllers consuming the cluster inventory API.
+package util
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+// ConditionChangedPredicate returns a predicate passing cluster updates that change the
+// status of the given condition type. A missing condition is treated as Unknown.
+// Create, delete and generic events always pass, as do updates of other objects.
+func ConditionChangedPredicate(conditionType string) predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCluster, ok := e.ObjectOld.(*v1alpha1.Cluster)
+			if !ok {
+				return true
+			}
+			newCluster, ok := e.ObjectNew.(*v1alpha1.Cluster)
+			if !ok {
+				return true
+			}
+			return oldCluster.ConditionStatus(conditionType) != newCluster.ConditionStatus(conditionType)
+		},
+	}
+}
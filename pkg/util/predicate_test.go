@@ -0,0 +1,90 @@
+package util
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+func newCluster(conditions ...metav1.Condition) *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1"},
+		Status:     v1alpha1.ClusterStatus{Conditions: conditions},
+	}
+}
+
+func newCondition(condType string, status metav1.ConditionStatus, reason string) metav1.Condition {
+	return metav1.Condition{Type: condType, Status: status, Reason: reason}
+}
+
+func TestConditionChangedPredicate(t *testing.T) {
+	healthy := newCondition(v1alpha1.ClusterConditionHealthy, metav1.ConditionTrue, "Healthy")
+	unhealthy := newCondition(v1alpha1.ClusterConditionHealthy, metav1.ConditionFalse, "Unhealthy")
+	unknown := newCondition(v1alpha1.ClusterConditionHealthy, metav1.ConditionUnknown, "Unknown")
+	joined := newCondition(v1alpha1.ClusterConditionJoined, metav1.ConditionTrue, "Joined")
+
+	cases := []struct {
+		name     string
+		oldObj   client.Object
+		newObj   client.Object
+		expected bool
+	}{
+		{
+			name:     "status changed",
+			oldObj:   newCluster(healthy),
+			newObj:   newCluster(unhealthy),
+			expected: true,
+		},
+		{
+			name:   "status unchanged",
+			oldObj: newCluster(healthy),
+			newObj: newCluster(newCondition(v1alpha1.ClusterConditionHealthy, metav1.ConditionTrue, "StillHealthy")),
+		},
+		{
+			name:   "other condition changed",
+			oldObj: newCluster(healthy),
+			newObj: newCluster(healthy, joined),
+		},
+		{
+			name:     "condition added",
+			oldObj:   newCluster(),
+			newObj:   newCluster(healthy),
+			expected: true,
+		},
+		{
+			name:   "missing condition is unknown",
+			oldObj: newCluster(),
+			newObj: newCluster(unknown),
+		},
+		{
+			name:     "other objects pass",
+			oldObj:   &corev1.ConfigMap{},
+			newObj:   &corev1.ConfigMap{},
+			expected: true,
+		},
+	}
+
+	p := ConditionChangedPredicate(v1alpha1.ClusterConditionHealthy)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := p.Update(event.UpdateEvent{ObjectOld: c.oldObj, ObjectNew: c.newObj}); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+
+	if !p.Create(event.CreateEvent{Object: newCluster()}) {
+		t.Errorf("expected create events to pass")
+	}
+	if !p.Delete(event.DeleteEvent{Object: newCluster()}) {
+		t.Errorf("expected delete events to pass")
+	}
+	if !p.Generic(event.GenericEvent{Object: newCluster()}) {
+		t.Errorf("expected generic events to pass")
+	}
+}
@@ -0,0 +1,23 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+// ComputeSecretVersionHash returns the hex encoded SHA-256 hash of the resource version
+// of the secret, as stored in the SecretVersionHash of access refs.
+func ComputeSecretVersionHash(secret *corev1.Secret) string {
+	sum := sha256.Sum256([]byte(secret.ResourceVersion))
+	return hex.EncodeToString(sum[:])
+}
+
+// NeedsRefresh returns true if the credentials cached for the access ref are stale
+// because the current secret differs from the version recorded in the access ref.
+func NeedsRefresh(ref v1alpha1.AccessObjectRef, current *corev1.Secret) bool {
+	return ref.SecretVersionHash != ComputeSecretVersionHash(current)
+}
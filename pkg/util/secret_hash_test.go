@@ -0,0 +1,62 @@
+package util
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+func newSecret(resourceVersion string) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "kubeconfig", Namespace: "fleet", ResourceVersion: resourceVersion}}
+}
+
+func TestComputeSecretVersionHash(t *testing.T) {
+	hash := ComputeSecretVersionHash(newSecret("1"))
+	if len(hash) != 64 {
+		t.Errorf("expected a hex encoded SHA-256 hash, got %q", hash)
+	}
+	if again := ComputeSecretVersionHash(newSecret("1")); again != hash {
+		t.Errorf("expected the hash to be stable, got %q and %q", hash, again)
+	}
+	if changed := ComputeSecretVersionHash(newSecret("2")); changed == hash {
+		t.Errorf("expected the hash to change with the resource version, got %q", changed)
+	}
+}
+
+func TestNeedsRefresh(t *testing.T) {
+	cases := []struct {
+		name     string
+		hash     string
+		current  *corev1.Secret
+		expected bool
+	}{
+		{
+			name:    "same resource version",
+			hash:    ComputeSecretVersionHash(newSecret("1")),
+			current: newSecret("1"),
+		},
+		{
+			name:     "rotated secret",
+			hash:     ComputeSecretVersionHash(newSecret("1")),
+			current:  newSecret("2"),
+			expected: true,
+		},
+		{
+			name:     "no recorded hash",
+			current:  newSecret("1"),
+			expected: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ref := v1alpha1.AccessObjectRef{Type: v1alpha1.AccessTypeKubeConfig, Resource: "secrets", Name: "kubeconfig", SecretVersionHash: c.hash}
+			if actual := NeedsRefresh(ref, c.current); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
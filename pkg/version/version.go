@@ -0,0 +1,42 @@
+// Package version exposes the version of the cluster inventory API module. The
+// variables are meant to be set at build time, for example:
+//
+//	go build -ldflags "-X github.com/qiujian16/cluster-inventory-api/pkg/version.Version=v0.1.0"
+package version
+
+import (
+	"fmt"
+	"runtime"
+)
+
+var (
+	// Version is the semantic version of the module.
+	Version = "unknown"
+	// GitCommit is the git commit the module was built from.
+	GitCommit = "unknown"
+	// BuildDate is the date the module was built at.
+	BuildDate = "unknown"
+)
+
+// VersionInfo holds the version information of the module.
+type VersionInfo struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit"`
+	BuildDate string `json:"buildDate"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Info returns the version information of the module.
+func Info() VersionInfo {
+	return VersionInfo{
+		Version:   Version,
+		GitCommit: GitCommit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+}
+
+// String returns the version information in a human readable form.
+func (info VersionInfo) String() string {
+	return fmt.Sprintf("%s (commit %s, built %s, %s)", info.Version, info.GitCommit, info.BuildDate, info.GoVersion)
+}
@@ -0,0 +1,50 @@
+package version
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestInfo(t *testing.T) {
+	cases := []struct {
+		name      string
+		version   string
+		gitCommit string
+		buildDate string
+	}{
+		{
+			name:      "defaults",
+			version:   "unknown",
+			gitCommit: "unknown",
+			buildDate: "unknown",
+		},
+		{
+			name:      "set at build time",
+			version:   "v0.1.0",
+			gitCommit: "abc123",
+			buildDate: "2024-01-01T00:00:00Z",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			defer func(version, gitCommit, buildDate string) {
+				Version, GitCommit, BuildDate = version, gitCommit, buildDate
+			}(Version, GitCommit, BuildDate)
+			Version, GitCommit, BuildDate = c.version, c.gitCommit, c.buildDate
+
+			expected := VersionInfo{Version: c.version, GitCommit: c.gitCommit, BuildDate: c.buildDate, GoVersion: runtime.Version()}
+			if info := Info(); info != expected {
+				t.Errorf("expected %#v, got %#v", expected, info)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	info := VersionInfo{Version: "v0.1.0", GitCommit: "abc123", BuildDate: "2024-01-01", GoVersion: "go1.21.0"}
+	expected := "v0.1.0 (commit abc123, built 2024-01-01, go1.21.0)"
+	if actual := info.String(); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+// newKubeConfigSecret returns a kubeconfig secret of the cluster returned by newCluster
+// whose user authenticates with a client certificate expiring at notAfter.
+func newKubeConfigSecret(t *testing.T, notAfter time.Time) *corev1.Secret {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "user"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	data, err := clientcmd.Write(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{"cluster": {Server: "https://api.example.com"}},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{"user": {
+			ClientCertificateData: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		}},
+		Contexts:       map[string]*clientcmdapi.Context{"context": {Cluster: "cluster", AuthInfo: "user"}},
+		CurrentContext: "context",
+	})
+	if err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "fleet", Name: "kubeconfig"},
+		Data:       map[string][]byte{v1alpha1.KubeConfigSecretKey: data},
+	}
+}
+
+func TestKubeConfigExpiryWarnings(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name            string
+		secret          *corev1.Secret
+		expectedWarning string
+	}{
+		{
+			name:   "far from expiry",
+			secret: newKubeConfigSecret(t, now.Add(kubeConfigExpiryWarningPeriod+time.Hour)),
+		},
+		{
+			name:            "near expiry",
+			secret:          newKubeConfigSecret(t, now.Add(24*time.Hour)),
+			expectedWarning: "spec.accessObjectRef[0]: client certificate of the kubeconfig in secret fleet/kubeconfig expires at 2024-06-02T00:00:00Z",
+		},
+		{
+			name:            "expired",
+			secret:          newKubeConfigSecret(t, now.Add(-24*time.Hour)),
+			expectedWarning: "spec.accessObjectRef[0]: client certificate of the kubeconfig in secret fleet/kubeconfig expired at 2024-05-31T00:00:00Z",
+		},
+		{
+			name: "invalid kubeconfig",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "fleet", Name: "kubeconfig"},
+				Data:       map[string][]byte{v1alpha1.KubeConfigSecretKey: []byte("not a kubeconfig")},
+			},
+		},
+		{
+			name: "missing secret",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var objs []client.Object
+			if c.secret != nil {
+				objs = append(objs, c.secret)
+			}
+			v := &ClusterValidator{Client: newFakeClient(t, nil, objs...), Namespace: testNamespace}
+
+			warnings := v.kubeConfigExpiryWarnings(context.Background(), newCluster(), now)
+			if c.expectedWarning == "" {
+				if len(warnings) != 0 {
+					t.Errorf("expected no warnings, got %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || warnings[0] != c.expectedWarning {
+				t.Errorf("expected warning %q, got %v", c.expectedWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestValidateCreateWarnsAboutExpiringCertificates(t *testing.T) {
+	secret := newKubeConfigSecret(t, time.Now().Add(time.Hour))
+	v := &ClusterValidator{Client: newFakeClient(t, nil, secret), Namespace: testNamespace}
+
+	warnings, err := v.ValidateCreate(context.Background(), newCluster())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "expires at") {
+		t.Errorf("expected an expiry warning, got %v", warnings)
+	}
+}
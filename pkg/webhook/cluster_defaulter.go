@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/mutate-multicluster-x-k8s-io-v1alpha1-cluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=multicluster.x-k8s.io,resources=clusters,verbs=create;update,versions=v1alpha1,name=mcluster.multicluster.x-k8s.io,admissionReviewVersions=v1
+
+// ClusterDefaulter mutates clusters on create and update.
+type ClusterDefaulter struct{}
+
+var _ admission.CustomDefaulter = &ClusterDefaulter{}
+
+// Default normalizes the taint keys of the cluster. The request is rejected if a taint
+// key is still invalid after normalization.
+func (d *ClusterDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	cluster, err := toCluster(obj)
+	if err != nil {
+		return err
+	}
+
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec", "taints")
+	for i := range cluster.Spec.Taints {
+		key := NormalizeTaintKey(cluster.Spec.Taints[i].Key)
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("key"), cluster.Spec.Taints[i].Key, strings.Join(errs, "; ")))
+			continue
+		}
+		cluster.Spec.Taints[i].Key = key
+	}
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(v1alpha1.Kind("Cluster"), cluster.Name, allErrs)
+	}
+	return nil
+}
+
+// NormalizeTaintKey lowercases the DNS subdomain prefix of a taint key, the part before
+// the "/", and leaves the name part untouched. Keys without a prefix are returned as is.
+func NormalizeTaintKey(key string) string {
+	prefix, name, found := strings.Cut(key, "/")
+	if !found {
+		return key
+	}
+	return strings.ToLower(prefix) + "/" + name
+}
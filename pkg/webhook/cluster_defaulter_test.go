@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+func TestNormalizeTaintKey(t *testing.T) {
+	cases := []struct {
+		key      string
+		expected string
+	}{
+		{key: "Foo", expected: "Foo"},
+		{key: "Example.COM/Foo", expected: "example.com/Foo"},
+		{key: "example.com/foo", expected: "example.com/foo"},
+		{key: "", expected: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.key, func(t *testing.T) {
+			if actual := NormalizeTaintKey(c.key); actual != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestClusterDefaulter(t *testing.T) {
+	cases := []struct {
+		name          string
+		keys          []string
+		expectedKeys  []string
+		expectInvalid bool
+	}{
+		{
+			name:         "prefixes are lowercased",
+			keys:         []string{"Example.com/Foo", "bar"},
+			expectedKeys: []string{"example.com/Foo", "bar"},
+		},
+		{
+			name:          "invalid name is rejected",
+			keys:          []string{"Example.com/-foo"},
+			expectInvalid: true,
+		},
+		{
+			name:          "invalid prefix is rejected",
+			keys:          []string{"example_com/foo"},
+			expectInvalid: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster()
+			for _, key := range c.keys {
+				cluster.Spec.Taints = append(cluster.Spec.Taints, v1alpha1.Taint{Key: key, Effect: v1alpha1.TaintEffectNoSelect})
+			}
+
+			err := (&ClusterDefaulter{}).Default(context.Background(), cluster)
+			if c.expectInvalid {
+				if !apierrors.IsInvalid(err) {
+					t.Fatalf("expected invalid error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for i, key := range c.expectedKeys {
+				if cluster.Spec.Taints[i].Key != key {
+					t.Errorf("expected key %q, got %q", key, cluster.Spec.Taints[i].Key)
+				}
+			}
+		})
+	}
+}
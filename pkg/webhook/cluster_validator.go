@@ -0,0 +1,224 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+	"github.com/qiujian16/cluster-inventory-api/pkg/accessutil"
+)
+
+// +kubebuilder:webhook:path=/validate-multicluster-x-k8s-io-v1alpha1-cluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=multicluster.x-k8s.io,resources=clusters;clusters/status,verbs=create;update,versions=v1alpha1,name=vcluster.multicluster.x-k8s.io,admissionReviewVersions=v1
+
+// kubeConfigExpiryWarningPeriod is the period before the expiry of a kubeconfig client
+// certificate during which the validator warns about it.
+const kubeConfigExpiryWarningPeriod = 30 * 24 * time.Hour
+
+// ClusterValidator validates clusters on create and update. It is registered for both
+// the clusters resource and its status subresource: updates of the resource validate
+// the spec, while updates of the status subresource validate the status, since the API
+// server ignores changes to the other part.
+type ClusterValidator struct {
+	// Client reads the ClusterWebhookConfiguration ConfigMap and the kubeconfig secrets
+	// of access refs. It should not be backed by an informer cache, which would watch
+	// every secret of the cluster. When nil, the default configuration is used and
+	// secrets are not read.
+	Client client.Reader
+
+	// Namespace is the namespace of the ClusterWebhookConfiguration ConfigMap.
+	Namespace string
+
+	// StrictPropertyNames, when set, overrides the strictPropertyNames key of the
+	// ClusterWebhookConfiguration ConfigMap, so that deployments without the ConfigMap
+	// can still reject property names colliding case-insensitively.
+	StrictPropertyNames *bool
+}
+
+var _ admission.CustomValidator = &ClusterValidator{}
+
+// ValidateCreate validates a cluster on creation.
+func (v *ClusterValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cluster, err := toCluster(obj)
+	if err != nil {
+		return nil, err
+	}
+	return v.validate(ctx, cluster, nil, v1alpha1.ValidateCluster(cluster))
+}
+
+// ValidateUpdate validates a cluster on update.
+func (v *ClusterValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldCluster, err := toCluster(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := toCluster(newObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if isStatusRequest(ctx) {
+		return v.validateStatus(ctx, cluster)
+	}
+
+	allErrs := v1alpha1.ValidateClusterSpec(&cluster.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, v1alpha1.ValidateClusterUpdate(cluster, oldCluster)...)
+	return v.validate(ctx, cluster, oldCluster, allErrs)
+}
+
+// ValidateDelete admits every deletion.
+func (v *ClusterValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate applies the configurable policies to the cluster and returns them along with
+// allErrs as an admission response. oldCluster is nil on creation.
+func (v *ClusterValidator) validate(ctx context.Context, cluster, oldCluster *v1alpha1.Cluster, allErrs field.ErrorList) (admission.Warnings, error) {
+	config, err := v.loadConfiguration(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings admission.Warnings
+
+	fldPath := field.NewPath("spec", "accessObjectRef")
+	for i, ref := range cluster.Spec.AccessObjectRefs {
+		if !ref.TLSInsecureSkipVerify {
+			continue
+		}
+		refPath := fldPath.Index(i).Child("tlsInsecureSkipVerify")
+		switch config.InsecureTLSPolicy {
+		case InsecureTLSPolicyDisallow:
+			allErrs = append(allErrs, field.Forbidden(refPath, "skipping TLS verification is not allowed"))
+		case InsecureTLSPolicyWarnOnly:
+			warnings = append(warnings, fmt.Sprintf("%s: skipping TLS verification is insecure", refPath))
+		}
+	}
+
+	if oldCluster != nil && config.MonotonicTaintEffects {
+		allErrs = append(allErrs, v1alpha1.ValidateTaintEffectTransitions(cluster, oldCluster)...)
+	}
+
+	warnings = append(warnings, v.kubeConfigExpiryWarnings(ctx, cluster, time.Now())...)
+
+	if len(allErrs) > 0 {
+		return warnings, apierrors.NewInvalid(v1alpha1.Kind("Cluster"), cluster.Name, allErrs)
+	}
+	return warnings, nil
+}
+
+// validateStatus validates an update of the status subresource of the cluster.
+func (v *ClusterValidator) validateStatus(ctx context.Context, cluster *v1alpha1.Cluster) (admission.Warnings, error) {
+	config, err := v.loadConfiguration(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fldPath := field.NewPath("status")
+	allErrs := v1alpha1.ValidateClusterStatus(&cluster.Status, fldPath)
+
+	var warnings admission.Warnings
+	for _, err := range validatePropertyNameCollisions(cluster.Status.Properties, fldPath.Child("properties")) {
+		if config.StrictPropertyNames {
+			allErrs = append(allErrs, err)
+			continue
+		}
+		warnings = append(warnings, err.Error())
+	}
+
+	if len(allErrs) > 0 {
+		return warnings, apierrors.NewInvalid(v1alpha1.Kind("Cluster"), cluster.Name, allErrs)
+	}
+	return warnings, nil
+}
+
+// loadConfiguration returns the webhook configuration, or the default configuration if
+// the validator has no client, with the overrides of the validator applied. A
+// configuration that cannot be loaded is returned as an internal error.
+func (v *ClusterValidator) loadConfiguration(ctx context.Context) (*ClusterWebhookConfiguration, error) {
+	config := DefaultClusterWebhookConfiguration()
+	if v.Client != nil {
+		var err error
+		if config, err = LoadClusterWebhookConfiguration(ctx, v.Client, v.Namespace); err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+	}
+	if v.StrictPropertyNames != nil {
+		config.StrictPropertyNames = *v.StrictPropertyNames
+	}
+	return config, nil
+}
+
+// isStatusRequest returns true if the admission request in the context targets the
+// status subresource.
+func isStatusRequest(ctx context.Context) bool {
+	req, err := admission.RequestFromContext(ctx)
+	return err == nil && req.SubResource == "status"
+}
+
+// kubeConfigExpiryWarnings returns a warning for every kubeconfig referenced by the
+// cluster whose client certificate has expired or expires within
+// kubeConfigExpiryWarningPeriod. The check is advisory, so secrets that cannot be read
+// or parsed are skipped.
+func (v *ClusterValidator) kubeConfigExpiryWarnings(ctx context.Context, cluster *v1alpha1.Cluster, now time.Time) admission.Warnings {
+	if v.Client == nil {
+		return nil
+	}
+
+	var warnings admission.Warnings
+	fldPath := field.NewPath("spec", "accessObjectRef")
+	for i, ref := range cluster.Spec.AccessObjectRefs {
+		if ref.Type != v1alpha1.AccessTypeKubeConfig || ref.ExternalSecretRef != nil || ref.Group != "" || ref.Resource != "secrets" {
+			continue
+		}
+		secret := &corev1.Secret{}
+		if err := v.Client.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+			continue
+		}
+		expiry, found, err := accessutil.KubeConfigCertExpiry(secret.Data[v1alpha1.KubeConfigSecretKey], "")
+		if err != nil || !found || expiry.Sub(now) > kubeConfigExpiryWarningPeriod {
+			continue
+		}
+		verb := "expires"
+		if !expiry.After(now) {
+			verb = "expired"
+		}
+		warnings = append(warnings, fmt.Sprintf("%s: client certificate of the kubeconfig in secret %s/%s %s at %s",
+			fldPath.Index(i), ref.Namespace, ref.Name, verb, expiry.UTC().Format(time.RFC3339)))
+	}
+	return warnings
+}
+
+// validatePropertyNameCollisions returns an error for every property whose name equals
+// the name of a previous property when compared case-insensitively.
+func validatePropertyNameCollisions(properties []v1alpha1.Property, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]string{}
+	for i, p := range properties {
+		lower := strings.ToLower(p.Name)
+		if name, ok := seen[lower]; ok && name != p.Name {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), p.Name,
+				fmt.Sprintf("collides case-insensitively with property %q", name)))
+			continue
+		}
+		seen[lower] = p.Name
+	}
+	return allErrs
+}
+
+func toCluster(obj runtime.Object) (*v1alpha1.Cluster, error) {
+	cluster, ok := obj.(*v1alpha1.Cluster)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Cluster but got a %T", obj))
+	}
+	return cluster, nil
+}
@@ -0,0 +1,329 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+func newCluster() *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "fleet"},
+		Spec: v1alpha1.ClusterSpec{
+			AccessObjectRefs: []v1alpha1.AccessObjectRef{
+				{Type: v1alpha1.AccessTypeKubeConfig, Resource: "secrets", Name: "kubeconfig", Namespace: "fleet"},
+			},
+			HealthProbe: v1alpha1.HealthProbe{HeartbeatIntervalSeconds: 60},
+		},
+	}
+}
+
+func requestContext(subResource string) context.Context {
+	return admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{SubResource: subResource},
+	})
+}
+
+func TestValidateUpdateStatus(t *testing.T) {
+	duplicateProperties := []v1alpha1.Property{
+		{Name: "id.k8s.io", Value: "a"},
+		{Name: "id.k8s.io", Value: "b"},
+	}
+
+	cases := []struct {
+		name          string
+		subResource   string
+		mutate        func(cluster *v1alpha1.Cluster)
+		expectInvalid bool
+	}{
+		{
+			name:        "valid status",
+			subResource: "status",
+			mutate: func(cluster *v1alpha1.Cluster) {
+				cluster.Status.Properties = []v1alpha1.Property{{Name: "id.k8s.io", Value: "a"}}
+			},
+		},
+		{
+			name:        "invalid status is rejected on the status subresource",
+			subResource: "status",
+			mutate: func(cluster *v1alpha1.Cluster) {
+				cluster.Status.Properties = duplicateProperties
+			},
+			expectInvalid: true,
+		},
+		{
+			name:        "invalid resources are rejected on the status subresource",
+			subResource: "status",
+			mutate: func(cluster *v1alpha1.Cluster) {
+				cluster.Status.Resources.Capacity = v1alpha1.ResourceList{v1alpha1.ResourceCPU: resource.MustParse("4")}
+			},
+			expectInvalid: true,
+		},
+		{
+			name:        "invalid control plane endpoint is rejected on the status subresource",
+			subResource: "status",
+			mutate: func(cluster *v1alpha1.Cluster) {
+				cluster.Status.ControlPlaneEndpoint = "not a url"
+			},
+			expectInvalid: true,
+		},
+		{
+			name: "status is ignored on the main resource",
+			mutate: func(cluster *v1alpha1.Cluster) {
+				cluster.Status.Properties = duplicateProperties
+			},
+		},
+		{
+			name: "spec is validated on the main resource",
+			mutate: func(cluster *v1alpha1.Cluster) {
+				cluster.Spec.ReadinessGates = []string{"not a qualified name"}
+			},
+			expectInvalid: true,
+		},
+		{
+			name:        "spec is ignored on the status subresource",
+			subResource: "status",
+			mutate: func(cluster *v1alpha1.Cluster) {
+				cluster.Spec.ReadinessGates = []string{"not a qualified name"}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			oldCluster := newCluster()
+			cluster := newCluster()
+			c.mutate(cluster)
+
+			v := &ClusterValidator{}
+			_, err := v.ValidateUpdate(requestContext(c.subResource), oldCluster, cluster)
+			if c.expectInvalid != apierrors.IsInvalid(err) {
+				t.Errorf("expected invalid %v, got %v", c.expectInvalid, err)
+			}
+		})
+	}
+}
+
+func TestInsecureTLSPolicy(t *testing.T) {
+	cases := []struct {
+		name           string
+		data           map[string]string
+		expectInvalid  bool
+		expectWarnings bool
+	}{
+		{
+			name:          "disallowed by default",
+			expectInvalid: true,
+		},
+		{
+			name:          "disallow",
+			data:          map[string]string{insecureTLSPolicyKey: string(InsecureTLSPolicyDisallow)},
+			expectInvalid: true,
+		},
+		{
+			name:           "warn only",
+			data:           map[string]string{insecureTLSPolicyKey: string(InsecureTLSPolicyWarnOnly)},
+			expectWarnings: true,
+		},
+		{
+			name: "allow",
+			data: map[string]string{insecureTLSPolicyKey: string(InsecureTLSPolicyAllow)},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := &ClusterValidator{Client: newFakeClient(t, c.data), Namespace: testNamespace}
+			cluster := newCluster()
+			cluster.Spec.AccessObjectRefs[0].TLSInsecureSkipVerify = true
+
+			warnings, err := v.ValidateCreate(context.Background(), cluster)
+			if c.expectInvalid != apierrors.IsInvalid(err) {
+				t.Errorf("expected invalid %v, got %v", c.expectInvalid, err)
+			}
+			if c.expectWarnings != (len(warnings) > 0) {
+				t.Errorf("expected warnings %v, got %v", c.expectWarnings, warnings)
+			}
+
+			// Access refs verifying TLS are admitted whatever the policy.
+			cluster.Spec.AccessObjectRefs[0].TLSInsecureSkipVerify = false
+			if warnings, err := v.ValidateCreate(context.Background(), cluster); err != nil || len(warnings) > 0 {
+				t.Errorf("expected no error and no warnings, got %v and %v", err, warnings)
+			}
+		})
+	}
+}
+
+func TestInvalidConfiguration(t *testing.T) {
+	v := &ClusterValidator{Client: newFakeClient(t, map[string]string{insecureTLSPolicyKey: "Sometimes"}), Namespace: testNamespace}
+	if _, err := v.ValidateCreate(context.Background(), newCluster()); !apierrors.IsInternalError(err) {
+		t.Errorf("expected an internal error, got %v", err)
+	}
+}
+
+func TestStrictPropertyNames(t *testing.T) {
+	enabled, disabled := true, false
+
+	cases := []struct {
+		name           string
+		data           map[string]string
+		noClient       bool
+		override       *bool
+		expectInvalid  bool
+		expectWarnings bool
+	}{
+		{
+			name:           "warns by default",
+			expectWarnings: true,
+		},
+		{
+			name:           "disabled",
+			data:           map[string]string{strictPropertyNamesKey: "false"},
+			expectWarnings: true,
+		},
+		{
+			name:          "enabled",
+			data:          map[string]string{strictPropertyNamesKey: "true"},
+			expectInvalid: true,
+		},
+		{
+			name:          "enabled by the validator over the ConfigMap",
+			data:          map[string]string{strictPropertyNamesKey: "false"},
+			override:      &enabled,
+			expectInvalid: true,
+		},
+		{
+			name:           "disabled by the validator over the ConfigMap",
+			data:           map[string]string{strictPropertyNamesKey: "true"},
+			override:       &disabled,
+			expectWarnings: true,
+		},
+		{
+			name:          "enabled by the validator without client",
+			noClient:      true,
+			override:      &enabled,
+			expectInvalid: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := &ClusterValidator{Client: newFakeClient(t, c.data), Namespace: testNamespace, StrictPropertyNames: c.override}
+			if c.noClient {
+				v.Client = nil
+			}
+			cluster := newCluster()
+			cluster.Status.Properties = []v1alpha1.Property{
+				{Name: "id.k8s.io", Value: "a"},
+				{Name: "ID.k8s.io", Value: "b"},
+			}
+
+			warnings, err := v.ValidateUpdate(requestContext("status"), newCluster(), cluster)
+			if c.expectInvalid != apierrors.IsInvalid(err) {
+				t.Errorf("expected invalid %v, got %v", c.expectInvalid, err)
+			}
+			if c.expectWarnings != (len(warnings) > 0) {
+				t.Errorf("expected warnings %v, got %v", c.expectWarnings, warnings)
+			}
+		})
+	}
+}
+
+func TestMonotonicTaintEffects(t *testing.T) {
+	cases := []struct {
+		name          string
+		data          map[string]string
+		expectInvalid bool
+	}{
+		{
+			name: "disabled by default",
+		},
+		{
+			name: "disabled",
+			data: map[string]string{monotonicTaintEffectsKey: "false"},
+		},
+		{
+			name:          "enabled",
+			data:          map[string]string{monotonicTaintEffectsKey: "true"},
+			expectInvalid: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := &ClusterValidator{Client: newFakeClient(t, c.data), Namespace: testNamespace}
+			oldCluster := newCluster()
+			oldCluster.Spec.Taints = []v1alpha1.Taint{{Key: "example.com/maintenance", Effect: v1alpha1.TaintEffectNoSelect}}
+			cluster := newCluster()
+			cluster.Spec.Taints = []v1alpha1.Taint{{Key: "example.com/maintenance", Effect: v1alpha1.TaintEffectPreferNoSelect}}
+
+			_, err := v.ValidateUpdate(requestContext(""), oldCluster, cluster)
+			if c.expectInvalid != apierrors.IsInvalid(err) {
+				t.Errorf("expected invalid %v, got %v", c.expectInvalid, err)
+			}
+
+			// Creating a cluster with weak taints is always admitted.
+			if _, err := v.ValidateCreate(context.Background(), cluster); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateCreateAccessRefCredentials(t *testing.T) {
+	serviceAccount := &v1alpha1.ServiceAccountAccessConfig{ServiceAccountName: "cluster-reader"}
+
+	cases := []struct {
+		name          string
+		ref           v1alpha1.AccessObjectRef
+		expectInvalid bool
+	}{
+		{
+			name: "kubeconfig with name",
+			ref:  v1alpha1.AccessObjectRef{Type: v1alpha1.AccessTypeKubeConfig, Resource: "secrets", Name: "kubeconfig", Namespace: "fleet"},
+		},
+		{
+			name: "service account with service account ref",
+			ref:  v1alpha1.AccessObjectRef{Type: v1alpha1.AccessTypeServiceAccount, Resource: "serviceaccounts", ServiceAccountRef: serviceAccount},
+		},
+		{
+			name:          "kubeconfig without name",
+			ref:           v1alpha1.AccessObjectRef{Type: v1alpha1.AccessTypeKubeConfig, Resource: "secrets", Namespace: "fleet"},
+			expectInvalid: true,
+		},
+		{
+			name:          "kubeconfig with service account ref",
+			ref:           v1alpha1.AccessObjectRef{Type: v1alpha1.AccessTypeKubeConfig, Resource: "secrets", Name: "kubeconfig", ServiceAccountRef: serviceAccount},
+			expectInvalid: true,
+		},
+		{
+			name:          "service account without service account ref",
+			ref:           v1alpha1.AccessObjectRef{Type: v1alpha1.AccessTypeServiceAccount, Resource: "serviceaccounts"},
+			expectInvalid: true,
+		},
+		{
+			name:          "service account with name",
+			ref:           v1alpha1.AccessObjectRef{Type: v1alpha1.AccessTypeServiceAccount, Resource: "serviceaccounts", Name: "kubeconfig", ServiceAccountRef: serviceAccount},
+			expectInvalid: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cluster := newCluster()
+			cluster.Spec.AccessObjectRefs = []v1alpha1.AccessObjectRef{c.ref}
+
+			_, err := (&ClusterValidator{}).ValidateCreate(context.Background(), cluster)
+			if c.expectInvalid != apierrors.IsInvalid(err) || (!c.expectInvalid && err != nil) {
+				t.Errorf("expected invalid %v, got %v", c.expectInvalid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ClusterWebhookConfigurationName is the name of the ConfigMap configuring the
+	// cluster webhooks.
+	ClusterWebhookConfigurationName = "cluster-webhook-configuration"
+
+	insecureTLSPolicyKey     = "insecureTLSPolicy"
+	monotonicTaintEffectsKey = "monotonicTaintEffects"
+	strictPropertyNamesKey   = "strictPropertyNames"
+)
+
+// InsecureTLSPolicy controls whether access refs may skip TLS verification.
+type InsecureTLSPolicy string
+
+const (
+	// InsecureTLSPolicyDisallow rejects access refs skipping TLS verification.
+	InsecureTLSPolicyDisallow InsecureTLSPolicy = "Disallow"
+	// InsecureTLSPolicyWarnOnly admits access refs skipping TLS verification with a
+	// warning.
+	InsecureTLSPolicyWarnOnly InsecureTLSPolicy = "WarnOnly"
+	// InsecureTLSPolicyAllow admits access refs skipping TLS verification.
+	InsecureTLSPolicyAllow InsecureTLSPolicy = "Allow"
+)
+
+// ClusterWebhookConfiguration is the configuration of the cluster webhooks, read from
+// the ClusterWebhookConfigurationName ConfigMap.
+type ClusterWebhookConfiguration struct {
+	// InsecureTLSPolicy controls whether access refs may skip TLS verification.
+	InsecureTLSPolicy InsecureTLSPolicy
+
+	// MonotonicTaintEffects rejects updates weakening the effect of a taint, unless
+	// the cluster allows it with an annotation. Disabled by default.
+	MonotonicTaintEffects bool
+
+	// StrictPropertyNames rejects clusters with property names that collide
+	// case-insensitively. When false, such collisions only produce warnings.
+	StrictPropertyNames bool
+}
+
+// DefaultClusterWebhookConfiguration returns the configuration used when the ConfigMap
+// does not exist.
+func DefaultClusterWebhookConfiguration() *ClusterWebhookConfiguration {
+	return &ClusterWebhookConfiguration{
+		InsecureTLSPolicy: InsecureTLSPolicyDisallow,
+	}
+}
+
+// LoadClusterWebhookConfiguration reads the webhook configuration from the
+// ClusterWebhookConfigurationName ConfigMap in the given namespace. Keys missing from
+// the ConfigMap, or a missing ConfigMap, take their default values.
+func LoadClusterWebhookConfiguration(ctx context.Context, reader client.Reader, namespace string) (*ClusterWebhookConfiguration, error) {
+	config := DefaultClusterWebhookConfiguration()
+
+	cm := &corev1.ConfigMap{}
+	err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ClusterWebhookConfigurationName}, cm)
+	switch {
+	case apierrors.IsNotFound(err):
+		return config, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if value, ok := cm.Data[insecureTLSPolicyKey]; ok {
+		switch policy := InsecureTLSPolicy(value); policy {
+		case InsecureTLSPolicyDisallow, InsecureTLSPolicyWarnOnly, InsecureTLSPolicyAllow:
+			config.InsecureTLSPolicy = policy
+		default:
+			return nil, fmt.Errorf("invalid %s %q in ConfigMap %s/%s", insecureTLSPolicyKey, value, namespace, ClusterWebhookConfigurationName)
+		}
+	}
+	if value, ok := cm.Data[monotonicTaintEffectsKey]; ok {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q in ConfigMap %s/%s", monotonicTaintEffectsKey, value, namespace, ClusterWebhookConfigurationName)
+		}
+		config.MonotonicTaintEffects = enabled
+	}
+	if value, ok := cm.Data[strictPropertyNamesKey]; ok {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q in ConfigMap %s/%s", strictPropertyNamesKey, value, namespace, ClusterWebhookConfigurationName)
+		}
+		config.StrictPropertyNames = enabled
+	}
+	return config, nil
+}
@@ -0,0 +1,96 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testNamespace = "cluster-inventory"
+
+// newFakeClient returns a fake client holding the objects, and the
+// ClusterWebhookConfiguration ConfigMap with data unless data is nil.
+func newFakeClient(t *testing.T, data map[string]string, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	if data != nil {
+		objs = append(objs, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: ClusterWebhookConfigurationName},
+			Data:       data,
+		})
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestLoadClusterWebhookConfiguration(t *testing.T) {
+	cases := []struct {
+		name        string
+		data        map[string]string
+		expected    *ClusterWebhookConfiguration
+		expectError bool
+	}{
+		{
+			name:     "no ConfigMap",
+			expected: DefaultClusterWebhookConfiguration(),
+		},
+		{
+			name:     "empty ConfigMap",
+			data:     map[string]string{},
+			expected: DefaultClusterWebhookConfiguration(),
+		},
+		{
+			name: "all keys",
+			data: map[string]string{
+				insecureTLSPolicyKey:     string(InsecureTLSPolicyWarnOnly),
+				monotonicTaintEffectsKey: "true",
+				strictPropertyNamesKey:   "true",
+			},
+			expected: &ClusterWebhookConfiguration{
+				InsecureTLSPolicy:     InsecureTLSPolicyWarnOnly,
+				MonotonicTaintEffects: true,
+				StrictPropertyNames:   true,
+			},
+		},
+		{
+			name:        "invalid insecure TLS policy",
+			data:        map[string]string{insecureTLSPolicyKey: "Sometimes"},
+			expectError: true,
+		},
+		{
+			name:        "invalid monotonic taint effects",
+			data:        map[string]string{monotonicTaintEffectsKey: "maybe"},
+			expectError: true,
+		},
+		{
+			name:        "invalid strict property names",
+			data:        map[string]string{strictPropertyNamesKey: "maybe"},
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config, err := LoadClusterWebhookConfiguration(context.Background(), newFakeClient(t, c.data), testNamespace)
+			if c.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got %#v", config)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *config != *c.expected {
+				t.Errorf("expected %#v, got %#v", c.expected, config)
+			}
+		})
+	}
+}
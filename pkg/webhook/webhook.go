@@ -0,0 +1,28 @@
+// Package webhook contains the admission webhooks for the cluster inventory API.
+package webhook
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/qiujian16/cluster-inventory-api/apis/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+
+// SetupWebhookWithManager registers the cluster webhooks with the manager. The
+// ClusterWebhookConfiguration ConfigMap is read from the given namespace, and the
+// kubeconfig secrets of access refs are read to warn about expiring client
+// certificates. Both are fetched through the uncached API reader of the manager, as a
+// cached client would start cluster-wide secret and ConfigMap informers and need list
+// and watch access to them.
+func SetupWebhookWithManager(mgr ctrl.Manager, namespace string) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.Cluster{}).
+		WithDefaulter(&ClusterDefaulter{}).
+		WithValidator(&ClusterValidator{
+			Client:    mgr.GetAPIReader(),
+			Namespace: namespace,
+		}).
+		Complete()
+}